@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var androidUnescaper = strings.NewReplacer(`\'`, `'`, `\"`, `"`, `\n`, "\n", `\t`, "\t", `\@`, `@`, `\?`, `?`, `\\`, `\`)
+
+var androidEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+type androidString struct {
+	Name string `xml:"name,attr"`
+	Text string `xml:",innerxml"`
+}
+
+type androidPlurals struct {
+	Name  string `xml:"name,attr"`
+	Items []struct {
+		Quantity string `xml:"quantity,attr"`
+		Text     string `xml:",innerxml"`
+	} `xml:"item"`
+}
+
+// AndroidParser returns a parser of Android strings.xml resources.
+//
+// <string> elements are parsed to messages, <plurals> elements are parsed to messages with the plural form of each
+// quantity, the comment before an element is used as the description.
+// Printf style placeholders are converted to template fields, the n-th argument (%s, %1$s, %d, ...) is converted to {{.argn}}.
+// In the plural forms, the first integer argument is the quantity and is converted to {{.PluralCount}}.
+func AndroidParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		decoder := xml.NewDecoder(bytes.NewReader(data))
+		var messages []translator.Message
+		var description string
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			switch t := token.(type) {
+			case xml.Comment:
+				description = strings.TrimSpace(string(t))
+			case xml.StartElement:
+				switch t.Name.Local {
+				case "resources":
+					continue
+				case "string":
+					var s androidString
+					if err := decoder.DecodeElement(&s, &t); err != nil {
+						return nil, err
+					}
+					messages = append(messages, Message(&i18n.Message{
+						ID:          s.Name,
+						Description: description,
+						Other:       androidToTemplate(s.Text),
+					}))
+				case "plurals":
+					var p androidPlurals
+					if err := decoder.DecodeElement(&p, &t); err != nil {
+						return nil, err
+					}
+					m := &i18n.Message{ID: p.Name, Description: description}
+					for _, item := range p.Items {
+						setMessageField(m, item.Quantity, printfToPluralTemplate(androidUnescape(item.Text)))
+					}
+					messages = append(messages, Message(m))
+				default:
+					if err := decoder.Skip(); err != nil {
+						return nil, err
+					}
+				}
+				description = ""
+			}
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// ExportAndroid encodes the message pack to Android strings.xml resources, it is the inverse of [AndroidParser].
+// Template fields are converted to positional placeholders, see [AndroidParser].
+func ExportAndroid(messagePack translator.MessagePack) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString(xml.Header)
+	buf.WriteString("<resources>\n")
+	for _, message := range messagePack.GetMessages() {
+		if strings.ContainsAny(message.GetID(), `"<>&`) {
+			return nil, exception.New(fmt.Sprintf("invalid resource name %q", message.GetID()))
+		}
+		if description := message.GetDescription(); description != "" {
+			buf.WriteString("    <!-- " + strings.ReplaceAll(description, "--", "- -") + " -->\n")
+		}
+		if !isPlural(message) {
+			buf.WriteString(`    <string name="` + message.GetID() + `">` + templateToAndroid(message.GetOther()) + "</string>\n")
+			continue
+		}
+		buf.WriteString(`    <plurals name="` + message.GetID() + "\">\n")
+		for _, form := range pluralForms(message) {
+			buf.WriteString(`        <item quantity="` + form[0] + `">` + templateToAndroid(form[1]) + "</item>\n")
+		}
+		buf.WriteString("    </plurals>\n")
+	}
+	buf.WriteString("</resources>\n")
+	return buf.Bytes(), nil
+}
+
+func androidToTemplate(text string) string {
+	return printfToTemplate(androidUnescape(text))
+}
+
+// androidUnescape returns the text of a resource string without its quotes and escapes.
+func androidUnescape(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "<![CDATA[", ""), "]]>", "")
+	text = html.UnescapeString(text)
+	if len(text) >= 2 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+		text = text[1 : len(text)-1]
+	}
+	return androidUnescaper.Replace(text)
+}
+
+func templateToAndroid(text string) string {
+	escaped := androidEscaper.Replace(templateToPrintf(text, "s"))
+	if strings.HasPrefix(escaped, "@") || strings.HasPrefix(escaped, "?") {
+		escaped = `\` + escaped
+	}
+	return escaped
+}
+
+// isPlural reports whether the message has any plural form other than "other".
+func isPlural(message translator.Message) bool {
+	return message.GetZero() != "" || message.GetOne() != "" || message.GetTwo() != "" ||
+		message.GetFew() != "" || message.GetMany() != ""
+}
+
+// pluralForms returns the non-empty plural forms of the message as category and text pairs.
+func pluralForms(message translator.Message) [][2]string {
+	var forms [][2]string
+	for _, form := range [][2]string{
+		{"zero", message.GetZero()},
+		{"one", message.GetOne()},
+		{"two", message.GetTwo()},
+		{"few", message.GetFew()},
+		{"many", message.GetMany()},
+		{"other", message.GetOther()},
+	} {
+		if form[1] != "" {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestAndroidParser(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+			return []byte(`<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- greeting on the home screen -->
+    <string name="welcome">Welcome, %1$s! You\'re &lt;b&gt;in&lt;/b&gt;.</string>
+    <string-array name="planets"><item>Mercury</item></string-array>
+    <plurals name="songs">
+        <item quantity="one">%d song found in %s</item>
+        <item quantity="other">%d songs found in %s</item>
+    </plurals>
+</resources>`), nil
+		}), AndroidParser(language.English))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "Welcome, world! You're <b>in</b>.", i.T("welcome", map[string]any{"arg1": "world"}))
+		assert.Equal(t, "1 song found in library", i.P("songs", 1, map[string]any{"arg2": "library"}))
+		assert.Equal(t, "2 songs found in library", i.P("songs", 2, "arg2", "library"))
+	}
+}
+
+func TestExportAndroid(t *testing.T) {
+	messagePack := MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "welcome", Description: "greeting", Other: "Welcome, {{.name}}! It's 100%"}),
+		Message(&i18n.Message{ID: "songs", One: "{{.PluralCount}} song", Other: "{{.PluralCount}} songs"}),
+	}, language.English)
+	content, err := ExportAndroid(messagePack)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Contains(t, string(content), `<!-- greeting -->`)
+	assert.Contains(t, string(content), `<string name="welcome">Welcome, %1$s! It\'s 100%%</string>`)
+	assert.Contains(t, string(content), `<item quantity="one">%1$d song</item>`)
+
+	parsed, err := AndroidParser(language.English).Parse(content)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	messages := parsed.GetMessages()
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "greeting", messages[0].GetDescription())
+		assert.Equal(t, "Welcome, {{.arg1}}! It's 100%", messages[0].GetOther())
+		assert.Equal(t, "{{.PluralCount}} songs", messages[1].GetOther())
+	}
+}
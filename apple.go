@@ -0,0 +1,368 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var appleStringsEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+var stringsdictVariable = regexp.MustCompile(`%#@(\w+)@`)
+
+// AppleStringsParser returns a parser of Apple .strings files.
+//
+// The block comment before an entry is used as the description, printf style placeholders are converted to
+// template fields, the n-th argument (%@, %1$@, %d, ...) is converted to {{.argn}}.
+func AppleStringsParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		s := &appleStringsScanner{src: []rune(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))}
+		var messages []translator.Message
+		for {
+			description, err := s.skipSpaceAndComments()
+			if err != nil {
+				return nil, err
+			}
+			if s.eof() {
+				break
+			}
+			key, err := s.scanString()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := s.skipSpaceAndComments(); err != nil {
+				return nil, err
+			}
+			if err := s.expect('='); err != nil {
+				return nil, err
+			}
+			if _, err := s.skipSpaceAndComments(); err != nil {
+				return nil, err
+			}
+			value, err := s.scanString()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := s.skipSpaceAndComments(); err != nil {
+				return nil, err
+			}
+			if err := s.expect(';'); err != nil {
+				return nil, err
+			}
+			messages = append(messages, Message(&i18n.Message{
+				ID:          key,
+				Description: description,
+				Other:       printfToTemplate(value),
+			}))
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// ExportAppleStrings encodes the messages of the message pack to an Apple .strings file,
+// it is the inverse of [AppleStringsParser].
+// Plural messages are skipped, they are exported by [ExportAppleStringsdict].
+func ExportAppleStrings(messagePack translator.MessagePack) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, message := range messagePack.GetMessages() {
+		if isPlural(message) {
+			continue
+		}
+		if description := message.GetDescription(); description != "" {
+			buf.WriteString("/* " + strings.ReplaceAll(description, "*/", "* /") + " */\n")
+		}
+		buf.WriteString(`"` + appleStringsEscaper.Replace(message.GetID()) + `" = "` +
+			appleStringsEscaper.Replace(templateToPrintf(message.GetOther(), "@")) + "\";\n\n")
+	}
+	return buf.Bytes(), nil
+}
+
+type appleStringsScanner struct {
+	src []rune
+	pos int
+}
+
+func (s *appleStringsScanner) eof() bool {
+	return s.pos >= len(s.src)
+}
+
+func (s *appleStringsScanner) errorf(format string, args ...any) error {
+	line := 1
+	for _, r := range s.src[:min(s.pos, len(s.src))] {
+		if r == '\n' {
+			line++
+		}
+	}
+	return exception.New(fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, args...)))
+}
+
+// skipSpaceAndComments skips the white spaces and comments, the last block comment is returned.
+func (s *appleStringsScanner) skipSpaceAndComments() (string, error) {
+	var comment string
+	for !s.eof() {
+		switch {
+		case unicode.IsSpace(s.src[s.pos]):
+			s.pos++
+		case s.hasPrefix("/*"):
+			end := s.index("*/", s.pos+2)
+			if end < 0 {
+				return "", s.errorf("unterminated comment")
+			}
+			comment = strings.TrimSpace(string(s.src[s.pos+2 : end]))
+			s.pos = end + 2
+		case s.hasPrefix("//"):
+			for !s.eof() && s.src[s.pos] != '\n' {
+				s.pos++
+			}
+		default:
+			return comment, nil
+		}
+	}
+	return comment, nil
+}
+
+func (s *appleStringsScanner) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(s.src[s.pos:min(s.pos+len(prefix), len(s.src))]), prefix)
+}
+
+// index returns the index of the first occurrence of substr from the given position, or -1.
+func (s *appleStringsScanner) index(substr string, from int) int {
+	sub := []rune(substr)
+	for i := from; i+len(sub) <= len(s.src); i++ {
+		if string(s.src[i:i+len(sub)]) == substr {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *appleStringsScanner) expect(r rune) error {
+	if s.eof() || s.src[s.pos] != r {
+		return s.errorf("expected %q", r)
+	}
+	s.pos++
+	return nil
+}
+
+func (s *appleStringsScanner) scanString() (string, error) {
+	if s.eof() {
+		return "", s.errorf("unexpected end of file")
+	}
+	if s.src[s.pos] != '"' {
+		start := s.pos
+		for !s.eof() && (unicode.IsLetter(s.src[s.pos]) || unicode.IsDigit(s.src[s.pos]) || strings.ContainsRune("_.-", s.src[s.pos])) {
+			s.pos++
+		}
+		if start == s.pos {
+			return "", s.errorf("expected string")
+		}
+		return string(s.src[start:s.pos]), nil
+	}
+	s.pos++
+	var sb strings.Builder
+	for !s.eof() {
+		r := s.src[s.pos]
+		s.pos++
+		switch r {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if s.eof() {
+				return "", s.errorf("unterminated string")
+			}
+			escaped := s.src[s.pos]
+			s.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'U', 'u':
+				if s.pos+4 > len(s.src) {
+					return "", s.errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(string(s.src[s.pos:s.pos+4]), 16, 16)
+				if err != nil {
+					return "", s.errorf("invalid unicode escape")
+				}
+				s.pos += 4
+				sb.WriteRune(rune(code))
+			default:
+				sb.WriteRune(escaped)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return "", s.errorf("unterminated string")
+}
+
+// AppleStringsdictParser returns a parser of Apple .stringsdict files.
+//
+// Each entry is parsed to a message with the plural forms of its variable, the entries with more than one
+// variable are not supported. Placeholders are converted as [AppleStringsParser] does, except the first integer
+// argument which is the quantity and is converted to {{.PluralCount}}.
+func AppleStringsdictParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		root, err := decodePlist(data)
+		if err != nil {
+			return nil, err
+		}
+		entries, ok := root.(map[string]any)
+		if !ok {
+			return nil, exception.New("invalid stringsdict, expected a dict")
+		}
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var messages []translator.Message
+		for _, key := range keys {
+			entry, ok := entries[key].(map[string]any)
+			if !ok {
+				return nil, exception.New(fmt.Sprintf("invalid stringsdict entry %q", key))
+			}
+			format, _ := entry["NSStringLocalizedFormatKey"].(string)
+			variables := stringsdictVariable.FindAllStringSubmatch(format, -1)
+			if len(variables) != 1 {
+				return nil, exception.New(fmt.Sprintf("unsupported stringsdict entry %q, expected exactly one variable", key))
+			}
+			rule, ok := entry[variables[0][1]].(map[string]any)
+			if !ok {
+				return nil, exception.New(fmt.Sprintf("missing variable %q of stringsdict entry %q", variables[0][1], key))
+			}
+			m := &i18n.Message{ID: key}
+			for _, form := range pluralKeys {
+				text, ok := rule[form].(string)
+				if !ok {
+					continue
+				}
+				setMessageField(m, form, printfToPluralTemplate(strings.Replace(format, variables[0][0], text, 1)))
+			}
+			messages = append(messages, Message(m))
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// ExportAppleStringsdict encodes the plural messages of the message pack to an Apple .stringsdict file,
+// it is the inverse of [AppleStringsdictParser].
+func ExportAppleStringsdict(messagePack translator.MessagePack) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	buf.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	for _, message := range messagePack.GetMessages() {
+		if !isPlural(message) {
+			continue
+		}
+		buf.WriteString("    <key>" + xmlEscape(message.GetID()) + "</key>\n    <dict>\n")
+		buf.WriteString("        <key>NSStringLocalizedFormatKey</key>\n        <string>%#@value@</string>\n")
+		buf.WriteString("        <key>value</key>\n        <dict>\n")
+		buf.WriteString("            <key>NSStringFormatSpecTypeKey</key>\n            <string>NSStringPluralRuleType</string>\n")
+		buf.WriteString("            <key>NSStringFormatValueTypeKey</key>\n            <string>d</string>\n")
+		for _, form := range pluralForms(message) {
+			buf.WriteString("            <key>" + form[0] + "</key>\n            <string>" + xmlEscape(templateToPrintf(form[1], "@")) + "</string>\n")
+		}
+		buf.WriteString("        </dict>\n    </dict>\n")
+	}
+	buf.WriteString("</dict>\n</plist>\n")
+	return buf.Bytes(), nil
+}
+
+func xmlEscape(s string) string {
+	buf := new(bytes.Buffer)
+	_ = xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
+
+// decodePlist decodes an XML property list, dicts are decoded to map[string]any, arrays to []any,
+// and the other values to strings.
+func decodePlist(data []byte) (any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, exception.New("invalid plist, missing root value")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local != "plist" {
+			return decodePlistValue(decoder, start)
+		}
+	}
+}
+
+func decodePlistValue(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		dict := make(map[string]any)
+		var key string
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				if t.Name.Local == "key" {
+					if err := decoder.DecodeElement(&key, &t); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				value, err := decodePlistValue(decoder, t)
+				if err != nil {
+					return nil, err
+				}
+				dict[key] = value
+			case xml.EndElement:
+				return dict, nil
+			}
+		}
+	case "array":
+		var array []any
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				value, err := decodePlistValue(decoder, t)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			case xml.EndElement:
+				return array, nil
+			}
+		}
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local, nil
+	default:
+		var value string
+		if err := decoder.DecodeElement(&value, &start); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
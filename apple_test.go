@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestAppleStringsParser(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`/* greeting on the home screen */
+"welcome" = "Welcome, %@! \"Enjoy\"";
+// line comment
+"transfer" = "%2$@ sent %1$@ \U2192 done";
+`), nil
+			}), AppleStringsParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, `Welcome, world! "Enjoy"`, i.T("welcome", map[string]any{"arg1": "world"}))
+			assert.Equal(t, "Bob sent $5 → done", i.T("transfer", map[string]any{"arg1": "$5", "arg2": "Bob"}))
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := AppleStringsParser(language.English).Parse([]byte(`"welcome" = "Welcome"`))
+		assert.Error(t, err)
+	})
+}
+
+func TestAppleStringsdictParser(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+			return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>files</key>
+    <dict>
+        <key>NSStringLocalizedFormatKey</key>
+        <string>You have %#@files@</string>
+        <key>files</key>
+        <dict>
+            <key>NSStringFormatSpecTypeKey</key>
+            <string>NSStringPluralRuleType</string>
+            <key>NSStringFormatValueTypeKey</key>
+            <string>d</string>
+            <key>one</key>
+            <string>%d file</string>
+            <key>other</key>
+            <string>%d files</string>
+        </dict>
+    </dict>
+</dict>
+</plist>`), nil
+		}), AppleStringsdictParser(language.English))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "You have 1 file", i.P("files", 1))
+		assert.Equal(t, "You have 3 files", i.P("files", 3))
+	}
+}
+
+func TestExportApple(t *testing.T) {
+	messagePack := MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "welcome", Description: "greeting", Other: "Welcome, {{.name}}!\n"}),
+		Message(&i18n.Message{ID: "files", One: "{{.PluralCount}} file", Other: "{{.PluralCount}} files"}),
+	}, language.English)
+
+	t.Run("strings", func(t *testing.T) {
+		content, err := ExportAppleStrings(messagePack)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "/* greeting */\n\"welcome\" = \"Welcome, %1$@!\\n\";\n\n", string(content))
+		parsed, err := AppleStringsParser(language.English).Parse(content)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if assert.Len(t, parsed.GetMessages(), 1) {
+			assert.Equal(t, "greeting", parsed.GetMessages()[0].GetDescription())
+			assert.Equal(t, "Welcome, {{.arg1}}!\n", parsed.GetMessages()[0].GetOther())
+		}
+	})
+
+	t.Run("stringsdict", func(t *testing.T) {
+		content, err := ExportAppleStringsdict(messagePack)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		parsed, err := AppleStringsdictParser(language.English).Parse(content)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if assert.Len(t, parsed.GetMessages(), 1) {
+			assert.Equal(t, "files", parsed.GetMessages()[0].GetID())
+			assert.Equal(t, "{{.PluralCount}} file", parsed.GetMessages()[0].GetOne())
+		}
+	})
+}
@@ -0,0 +1,414 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+type arbMetadata struct {
+	Description  string         `json:"description,omitempty"`
+	Placeholders map[string]any `json:"placeholders,omitempty"`
+}
+
+// ARBParser returns a parser of Flutter Application Resource Bundle (.arb) files.
+//
+// The language tag is read from "@@locale" and falls back to the given tag, "@key.description" is used as the
+// description. ICU placeholders {name} are converted to {{.name}} template fields, and a message with a
+// {count, plural, ...} argument is parsed to a message with the corresponding plural forms, where "=0", "=1" and "=2"
+// are used as the zero, one and two forms if the category is missing. The plural argument and # are converted to
+// {{.PluralCount}}, the count given by [I18n.P].
+// A {place, selectordinal, ...} argument is parsed the same way, for the ordinal forms of [I18n.PO].
+// A message with a {gender, select, ...} argument is parsed to a select variant for each branch, see [I18n.S],
+// the "other" branch is the message itself.
+func ARBParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		if locale, ok := raw["@@locale"]; ok {
+			var l string
+			if err := json.Unmarshal(locale, &l); err != nil {
+				return nil, err
+			}
+			t, err := language.Parse(strings.ReplaceAll(l, "_", "-"))
+			if err != nil {
+				return nil, err
+			}
+			tag = t
+		}
+		keys := make([]string, 0, len(raw))
+		for key := range raw {
+			if !strings.HasPrefix(key, "@") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		var messages []translator.Message
+		for _, key := range keys {
+			var text string
+			if err := json.Unmarshal(raw[key], &text); err != nil {
+				return nil, exception.New(fmt.Sprintf("invalid message %q: %s", key, err.Error()))
+			}
+			var description string
+			if metadata, ok := raw["@"+key]; ok {
+				var meta arbMetadata
+				if err := json.Unmarshal(metadata, &meta); err != nil {
+					return nil, exception.New(fmt.Sprintf("invalid metadata of message %q: %s", key, err.Error()))
+				}
+				description = meta.Description
+			}
+			variants, err := icuToVariants(text)
+			if err != nil {
+				return nil, exception.New(fmt.Sprintf("invalid message %q: %s", key, err.Error()))
+			}
+			for _, variant := range variants {
+				m := &i18n.Message{ID: key, Description: description}
+				if variant.selector != "" {
+					m.ID += SelectSeparator + variant.selector
+				}
+				for form, value := range variant.forms {
+					setMessageField(m, form, value)
+				}
+				messages = append(messages, Message(m))
+			}
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// ExportARB encodes the message pack to a Flutter Application Resource Bundle, it is the inverse of [ARBParser].
+// Template fields are exported as ICU placeholders and plural messages as {count, plural, ...} arguments.
+func ExportARB(messagePack translator.MessagePack) ([]byte, error) {
+	root := map[string]any{
+		"@@locale": strings.ReplaceAll(messagePack.GetLanguageTag().String(), "-", "_"),
+	}
+	for _, message := range messagePack.GetMessages() {
+		meta := arbMetadata{Description: message.GetDescription()}
+		var text string
+		if isPlural(message) {
+			var sb strings.Builder
+			sb.WriteString("{count, plural,")
+			for _, form := range pluralForms(message) {
+				sb.WriteString(" " + form[0] + "{" + templateToICU(form[1], meta.addPlaceholder) + "}")
+			}
+			sb.WriteString("}")
+			text = sb.String()
+			meta.addPlaceholder("count")
+		} else {
+			text = templateToICU(message.GetOther(), meta.addPlaceholder)
+		}
+		root[message.GetID()] = text
+		if meta.Description != "" || len(meta.Placeholders) > 0 {
+			root["@"+message.GetID()] = meta
+		}
+	}
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *arbMetadata) addPlaceholder(name string) {
+	if m.Placeholders == nil {
+		m.Placeholders = make(map[string]any)
+	}
+	m.Placeholders[name] = map[string]any{}
+}
+
+// icuNode is a node of an ICU message, which is a literal text, a # of a plural branch or an argument.
+type icuNode struct {
+	text     string
+	hash     bool
+	name     string
+	kind     string
+	branches [][2]string
+}
+
+// icuVariant is a select variant of an ICU message, the selector of the "other" branch is empty.
+type icuVariant struct {
+	selector string
+	forms    map[string]string
+}
+
+// icuToVariants converts the ICU message to the plural forms of its select variants, see [ARBParser].
+func icuToVariants(text string) ([]icuVariant, error) {
+	nodes, err := parseICU(text)
+	if err != nil {
+		return nil, err
+	}
+	selectIndex := -1
+	for index, node := range nodes {
+		if node.kind == "select" {
+			if selectIndex >= 0 {
+				return nil, exception.New("only one select argument is supported")
+			}
+			selectIndex = index
+		}
+	}
+	if selectIndex < 0 {
+		forms, err := icuToForms(nodes)
+		if err != nil {
+			return nil, err
+		}
+		return []icuVariant{{forms: forms}}, nil
+	}
+	var variants []icuVariant
+	hasOther := false
+	for _, branch := range nodes[selectIndex].branches {
+		branchNodes, err := parseICU(branch[1])
+		if err != nil {
+			return nil, err
+		}
+		variantNodes := append(append(append([]icuNode{}, nodes[:selectIndex]...), branchNodes...), nodes[selectIndex+1:]...)
+		forms, err := icuToForms(variantNodes)
+		if err != nil {
+			return nil, err
+		}
+		selector := branch[0]
+		if selector == "other" {
+			selector = ""
+			hasOther = true
+		}
+		variants = append(variants, icuVariant{selector: selector, forms: forms})
+	}
+	if !hasOther {
+		return nil, exception.New(fmt.Sprintf("missing other branch of argument %q", nodes[selectIndex].name))
+	}
+	return variants, nil
+}
+
+// icuToForms converts the nodes of the ICU message to plural forms, see [ARBParser].
+func icuToForms(nodes []icuNode) (map[string]string, error) {
+	pluralIndex := -1
+	for index, node := range nodes {
+		if node.kind == "plural" || node.kind == "selectordinal" {
+			if pluralIndex >= 0 {
+				return nil, exception.New("only one plural argument is supported")
+			}
+			pluralIndex = index
+		}
+	}
+	if pluralIndex < 0 {
+		other, err := renderICU(nodes, "", false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"other": other}, nil
+	}
+	plural := nodes[pluralIndex]
+	prefix, err := renderICU(nodes[:pluralIndex], plural.name, false)
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := renderICU(nodes[pluralIndex+1:], plural.name, false)
+	if err != nil {
+		return nil, err
+	}
+	forms := make(map[string]string)
+	exact := make(map[string]string)
+	for _, branch := range plural.branches {
+		branchNodes, err := parseICU(branch[1])
+		if err != nil {
+			return nil, err
+		}
+		value, err := renderICU(branchNodes, plural.name, true)
+		if err != nil {
+			return nil, err
+		}
+		switch branch[0] {
+		case "=0":
+			exact["zero"] = prefix + value + suffix
+		case "=1":
+			exact["one"] = prefix + value + suffix
+		case "=2":
+			exact["two"] = prefix + value + suffix
+		default:
+			forms[branch[0]] = prefix + value + suffix
+		}
+	}
+	for form, value := range exact {
+		if _, ok := forms[form]; !ok {
+			forms[form] = value
+		}
+	}
+	return forms, nil
+}
+
+// renderICU renders the nodes as a template, the plural argument and the # of a plural branch are rendered as
+// {{.PluralCount}}.
+func renderICU(nodes []icuNode, pluralArg string, branch bool) (string, error) {
+	var sb strings.Builder
+	for _, node := range nodes {
+		switch {
+		case node.hash:
+			if branch {
+				sb.WriteString("{{.PluralCount}}")
+			} else {
+				sb.WriteString("#")
+			}
+		case node.name != "":
+			if node.kind != "" && node.kind != "number" && node.kind != "date" && node.kind != "time" {
+				return "", exception.New(fmt.Sprintf("unsupported %s argument %q", node.kind, node.name))
+			}
+			if node.name == pluralArg {
+				sb.WriteString("{{.PluralCount}}")
+			} else {
+				sb.WriteString("{{." + node.name + "}}")
+			}
+		default:
+			sb.WriteString(node.text)
+		}
+	}
+	return sb.String(), nil
+}
+
+// parseICU parses the top level nodes of an ICU message.
+func parseICU(text string) ([]icuNode, error) {
+	var nodes []icuNode
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			nodes = append(nodes, icuNode{text: literal.String()})
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\'':
+			if i+1 < len(text) && text[i+1] == '\'' {
+				literal.WriteByte('\'')
+				i++
+			} else if i+1 < len(text) && strings.IndexByte("{}#|", text[i+1]) >= 0 {
+				end := strings.IndexByte(text[i+1:], '\'')
+				if end < 0 {
+					end = len(text) - i - 1
+				}
+				literal.WriteString(text[i+1 : i+1+end])
+				i += end + 1
+			} else {
+				literal.WriteByte(c)
+			}
+		case '#':
+			flush()
+			nodes = append(nodes, icuNode{hash: true})
+		case '{':
+			end := matchICUBrace(text, i)
+			if end < 0 {
+				return nil, exception.New("unbalanced braces")
+			}
+			flush()
+			node, err := parseICUArgument(text[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+			i = end
+		case '}':
+			return nil, exception.New("unbalanced braces")
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+	return nodes, nil
+}
+
+func parseICUArgument(inner string) (icuNode, error) {
+	parts := strings.SplitN(inner, ",", 3)
+	node := icuNode{name: strings.TrimSpace(parts[0])}
+	if node.name == "" {
+		return node, exception.New("empty argument")
+	}
+	if len(parts) == 1 {
+		return node, nil
+	}
+	node.kind = strings.TrimSpace(parts[1])
+	if node.kind != "plural" && node.kind != "select" && node.kind != "selectordinal" {
+		return node, nil
+	}
+	if len(parts) < 3 {
+		return node, exception.New(fmt.Sprintf("missing branches of argument %q", node.name))
+	}
+	rest := parts[2]
+	for {
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return node, nil
+		}
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			return node, exception.New(fmt.Sprintf("invalid branches of argument %q", node.name))
+		}
+		selector := strings.TrimSpace(rest[:open])
+		if strings.HasPrefix(selector, "offset:") {
+			fields := strings.Fields(selector)
+			selector = fields[len(fields)-1]
+		}
+		end := matchICUBrace(rest, open)
+		if end < 0 {
+			return node, exception.New("unbalanced braces")
+		}
+		node.branches = append(node.branches, [2]string{selector, rest[open+1 : end]})
+		rest = rest[end+1:]
+	}
+}
+
+// matchICUBrace returns the index of the brace closing the one at start, or -1.
+func matchICUBrace(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\'':
+			if i+1 < len(text) && text[i+1] == '\'' {
+				i++
+			} else if i+1 < len(text) && strings.IndexByte("{}#|", text[i+1]) >= 0 {
+				end := strings.IndexByte(text[i+1:], '\'')
+				if end < 0 {
+					return -1
+				}
+				i += end + 1
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// templateToICU converts the template fields to ICU placeholders, {{.PluralCount}} is converted to #.
+func templateToICU(text string, placeholder func(name string)) string {
+	quoter := strings.NewReplacer("'", "''", "{", "'{'", "}", "'}'", "#", "'#'")
+	var sb strings.Builder
+	last := 0
+	for _, loc := range templateField.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(quoter.Replace(text[last:loc[0]]))
+		name := text[loc[2]:loc[3]]
+		if name == "PluralCount" {
+			sb.WriteString("#")
+		} else {
+			sb.WriteString("{" + name + "}")
+			placeholder(name)
+		}
+		last = loc[1]
+	}
+	sb.WriteString(quoter.Replace(text[last:]))
+	return sb.String()
+}
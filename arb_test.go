@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestARBParser(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`{
+					"@@locale": "fr",
+					"welcome": "Bienvenue, {name} !",
+					"@welcome": {"description": "greeting", "placeholders": {"name": {"type": "String"}}},
+					"items": "Vous avez {count, plural, =0{aucun article} one{# article} other{# articles}}.",
+					"left": "{count, plural, one{{count} article} other{{count} articles}} restants",
+					"quoted": "Use '{braces}' here"
+				}`), nil
+			}), ARBParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			fr := i.Locale("fr")
+			assert.Equal(t, "Bienvenue, Alice !", fr.T("welcome", map[string]any{"name": "Alice"}))
+			assert.Equal(t, "Vous avez 1 article.", fr.P("items", 1))
+			assert.Equal(t, "Vous avez 3 articles.", fr.P("items", 3))
+			assert.Equal(t, "2 articles restants", fr.P("left", 2))
+			assert.Equal(t, "Use {braces} here", fr.T("quoted"))
+		}
+	})
+
+	t.Run("description", func(t *testing.T) {
+		messagePack, err := ARBParser(language.English).Parse([]byte(`{"welcome": "Hi", "@welcome": {"description": "greeting"}}`))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if assert.Len(t, messagePack.GetMessages(), 1) {
+			assert.Equal(t, "greeting", messagePack.GetMessages()[0].GetDescription())
+		}
+		assert.Equal(t, language.English, messagePack.GetLanguageTag())
+	})
+
+	t.Run("select", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`{
+					"invite": "{gender, select, female{{name} invited you to her party} male{{name} invited you to his party} other{{name} invited you to their party}}"
+				}`), nil
+			}), ARBParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Alice invited you to her party", i.S("invite", "female", "name", "Alice"))
+			assert.Equal(t, "Bob invited you to his party", i.S("invite", "male", "name", "Bob"))
+			assert.Equal(t, "Sam invited you to their party", i.S("invite", "", "name", "Sam"))
+		}
+	})
+
+	t.Run("selectordinal", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`{"place": "{place, selectordinal, one{#st} two{#nd} few{#rd} other{#th}} place"}`), nil
+			}), ARBParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "22nd place", i.PO("place", 22))
+			assert.Equal(t, "13th place", i.PO("place", 13))
+		}
+		_, err = ARBParser(language.English).Parse([]byte(`{"invite": "{gender, select, female{her} male{his}}"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ARBParser(language.English).Parse([]byte(`{"welcome": "Hi {name"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestExportARB(t *testing.T) {
+	content, err := ExportARB(MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "welcome", Description: "greeting", Other: "Welcome, {{.name}}!"}),
+		Message(&i18n.Message{ID: "items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"}),
+	}, language.MustParse("en-US")))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `{
+		"@@locale": "en_US",
+		"welcome": "Welcome, {name}!",
+		"@welcome": {"description": "greeting", "placeholders": {"name": {}}},
+		"items": "{count, plural, one{# item} other{# items}}",
+		"@items": {"placeholders": {"count": {}}}
+	}`, string(content))
+	parsed, err := ARBParser(language.English).Parse(content)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, language.MustParse("en-US"), parsed.GetLanguageTag())
+	if assert.Len(t, parsed.GetMessages(), 2) {
+		assert.Equal(t, "{{.PluralCount}} item", parsed.GetMessages()[0].GetOne())
+	}
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		if err := i.LoadMessage(LoaderFunc(func() ([]byte, error) { return content, nil }), ARBParser(language.English)); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "3 items", i.Locale("en-US").P("items", 3))
+	}
+}
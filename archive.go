@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gopi-frame/exception"
+	"golang.org/x/text/language"
+)
+
+// ArchiveEntryError is the error of an archive entry which can not be loaded.
+type ArchiveEntryError struct {
+	Name string
+	Err  error
+}
+
+func (e *ArchiveEntryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Err.Error())
+}
+
+func (e *ArchiveEntryError) Unwrap() error {
+	return e.Err
+}
+
+// LoadMessageArchive loads messages from a .zip or .tar.gz archive file.
+// Entries whose name ends with .{locale}.{format} are loaded through the registered unmarshal functions,
+// the others are ignored. The entries must be signed [Envelope]s once the public keys are set by [I18n.SetPublicKeys].
+// The returned error joins an [ArchiveEntryError] for each entry that can not be loaded.
+func (i *I18n) LoadMessageArchive(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return i.loadArchive(path, content)
+}
+
+// LoadMessageArchiveFS loads messages from a .zip or .tar.gz archive file from the given file system.
+func (i *I18n) LoadMessageArchiveFS(fsys fs.FS, path string) error {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+	return i.loadArchive(path, content)
+}
+
+func (i *I18n) loadArchive(path string, content []byte) error {
+	switch {
+	case strings.HasSuffix(path, ".zip"):
+		return i.LoadMessageZip(bytes.NewReader(content), int64(len(content)))
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		return i.LoadMessageTarGz(bytes.NewReader(content))
+	default:
+		return exception.New(fmt.Sprintf("unsupported archive format: %s", path))
+	}
+}
+
+// LoadMessageZip loads messages from a zip archive.
+func (i *I18n) LoadMessageZip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || !isMessageFile(file.Name) {
+			continue
+		}
+		if err := i.loadArchiveEntry(file.Name, file.Open); err != nil {
+			errs = append(errs, &ArchiveEntryError{Name: file.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadMessageTarGz loads messages from a gzip compressed tar archive.
+func (i *I18n) LoadMessageTarGz(r io.Reader) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := gr.Close(); err != nil {
+			panic(err)
+		}
+	}()
+	tr := tar.NewReader(gr)
+	var errs []error
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if header.Typeflag != tar.TypeReg || !isMessageFile(header.Name) {
+			continue
+		}
+		if err := i.loadArchiveEntry(header.Name, func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		}); err != nil {
+			errs = append(errs, &ArchiveEntryError{Name: header.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (i *I18n) loadArchiveEntry(name string, open func() (io.ReadCloser, error)) error {
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rc.Close(); err != nil {
+			panic(err)
+		}
+	}()
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	if len(i.publicKeys) > 0 {
+		if content, err = Open(content, i.publicKeys...); err != nil {
+			return err
+		}
+	}
+	return i.loadMessageFileBytes(content, name, "")
+}
+
+// isMessageFile reports whether the name follows the .{locale}.{format} naming of message files.
+func isMessageFile(name string) bool {
+	base := path.Base(name)
+	if strings.HasPrefix(base, ".") {
+		return false
+	}
+	parts := strings.Split(base, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	_, err := language.Parse(parts[len(parts)-2])
+	return err == nil
+}
@@ -0,0 +1,172 @@
+package i18n
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testArchiveFiles = map[string]string{
+	"locales/messages.en.json":    `[{"id": "test", "other": "test one"}]`,
+	"locales/messages.zh.json":    `[{"id": "test", "other": "测试一"}]`,
+	"locales/messages.fr.invalid": `[{"id": "test", "other": "test un"}]`,
+	"locales/README.md":           `# locales`,
+}
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return buf.Bytes()
+}
+
+func testTarGz(t *testing.T) []byte {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range testArchiveFiles {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	if err := tw.Close(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if err := gw.Close(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	return buf.Bytes()
+}
+
+func assertArchiveLoaded(t *testing.T, i *I18n, err error) {
+	var entryErr *ArchiveEntryError
+	if assert.True(t, errors.As(err, &entryErr)) {
+		assert.Equal(t, "locales/messages.fr.invalid", entryErr.Name)
+	}
+	assert.Equal(t, "test one", i.T("test"))
+	assert.Equal(t, "测试一", i.Locale("zh").T("test"))
+}
+
+func TestI18n_LoadMessageZip(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		content := testZip(t, testArchiveFiles)
+		err = i.LoadMessageZip(bytes.NewReader(content), int64(len(content)))
+		assertArchiveLoaded(t, i, err)
+	}
+
+	t.Run("scoped", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			content := testZip(t, testArchiveFiles)
+			err = i.Scope("app").(*I18n).LoadMessageZip(bytes.NewReader(content), int64(len(content)))
+			assert.Error(t, err)
+			assert.Equal(t, "test one", i.T("app.test"))
+			assert.Equal(t, "test", i.T("test"))
+		}
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		envelope, err := Sign(privateKey, []byte(`[{"id": "test", "other": "test one"}]`))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			i.SetPublicKeys(publicKey)
+			content := testZip(t, map[string]string{
+				"locales/messages.en.json": string(envelope),
+				"locales/messages.zh.json": `[{"id": "test", "other": "测试一"}]`,
+			})
+			err = i.LoadMessageZip(bytes.NewReader(content), int64(len(content)))
+			var entryErr *ArchiveEntryError
+			if assert.True(t, errors.As(err, &entryErr)) {
+				assert.Equal(t, "locales/messages.zh.json", entryErr.Name)
+			}
+			assert.Equal(t, "test one", i.T("test"))
+			assert.Equal(t, "test one", i.Locale("zh").T("test"))
+		}
+	})
+}
+
+func TestI18n_LoadMessageTarGz(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessageTarGz(bytes.NewReader(testTarGz(t)))
+		assertArchiveLoaded(t, i, err)
+	}
+}
+
+func TestI18n_LoadMessageArchive(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "locales.zip"), testZip(t, testArchiveFiles), 0600); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "locales.tar.gz"), testTarGz(t), 0600); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	t.Run("zip", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessageArchive(filepath.Join(dir, "locales.zip"))
+			assertArchiveLoaded(t, i, err)
+		}
+	})
+
+	t.Run("tar.gz from file system", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessageArchiveFS(os.DirFS(dir), "locales.tar.gz")
+			assertArchiveLoaded(t, i, err)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessageArchive("testdata/test.en.json")
+			assert.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+)
+
+const (
+	// firstStrongIsolate starts a text isolated from the surrounding text, its direction is given by its first
+	// strong character.
+	firstStrongIsolate = "\u2068"
+	// popDirectionalIsolate ends the isolated text.
+	popDirectionalIsolate = "\u2069"
+	// isolateFunc is the name of the template function added to the actions which print values.
+	isolateFunc = "_isolate"
+)
+
+// SetBidiIsolation sets whether the values printed by the message templates are wrapped in the Unicode isolation
+// marks FSI and PDI if the language resolved by the translator is written from right to left, so Latin names or
+// numbers given by the template data do not reorder the surrounding Arabic or Hebrew text.
+// The messages rendered by [I18n.HTML] are not isolated, use the bdi tag instead.
+func (i *I18n) SetBidiIsolation(enabled bool) {
+	i.bidiIsolation = enabled
+}
+
+// Direction returns the direction of the script of the language resolved by the translator,
+// for example, the dir attribute of the HTML of a translator returned by [I18n.Locale].
+func (i *I18n) Direction() Direction {
+	return i.resolve().direction
+}
+
+// textParser returns the parser of the message templates, the printed values are isolated
+// if the bidi isolation is enabled and the language resolved by the translator is written from right to left.
+func (i *I18n) textParser(funcs texttemplate.FuncMap) template.Parser {
+	if i.bidiIsolation && i.Direction() == RightToLeft {
+		return &bidiParser{funcs: funcs}
+	}
+	return &template.TextParser{Funcs: funcs}
+}
+
+// bidiParser parses the templates with text/template, the actions which print values are piped to isolateFunc.
+type bidiParser struct {
+	funcs texttemplate.FuncMap
+}
+
+func (p *bidiParser) Cacheable() bool {
+	return false
+}
+
+func (p *bidiParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	funcs := texttemplate.FuncMap{isolateFunc: isolate}
+	for name, f := range p.funcs {
+		funcs[name] = f
+	}
+	tmpl, err := texttemplate.New("").Delims(leftDelim, rightDelim).Funcs(funcs).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	isolateActions(tmpl.Tree, tmpl.Tree.Root)
+	return &parsedBidiTemplate{tmpl: tmpl}, nil
+}
+
+type parsedBidiTemplate struct {
+	tmpl *texttemplate.Template
+}
+
+func (t *parsedBidiTemplate) Execute(data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// isolateActions pipes the actions which print values to isolateFunc, the variable declarations print nothing.
+func isolateActions(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			isolateActions(tree, child)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) == 0 {
+			identifier := parse.NewIdentifier(isolateFunc).SetTree(tree).SetPos(n.Pos)
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{identifier}})
+		}
+	case *parse.IfNode:
+		isolateActions(tree, n.List)
+		isolateActions(tree, n.ElseList)
+	case *parse.RangeNode:
+		isolateActions(tree, n.List)
+		isolateActions(tree, n.ElseList)
+	case *parse.WithNode:
+		isolateActions(tree, n.List)
+		isolateActions(tree, n.ElseList)
+	}
+}
+
+func isolate(v any) string {
+	return firstStrongIsolate + fmt.Sprint(v) + popDirectionalIsolate
+}
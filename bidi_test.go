@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Direction(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		i.SetBidiIsolation(true)
+		before := i.Locale("ar").(*I18n)
+		for _, l := range []string{"ar", "he", "fa"} {
+			if err := i.AddMessages(l, Message(&i18n.Message{ID: "yes", Other: "yes"})); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		assert.Equal(t, LeftToRight, i.Direction())
+		assert.Equal(t, RightToLeft, i.Locale("ar-EG").(*I18n).Direction())
+		assert.Equal(t, RightToLeft, i.Locale("he").(*I18n).Direction())
+		assert.Equal(t, RightToLeft, i.Locale("fa").(*I18n).Direction())
+		assert.Equal(t, LeftToRight, i.Locale("ja").(*I18n).Direction())
+		assert.Equal(t, RightToLeft, before.Direction())
+		if err := i.AddMessages("ar", Message(&i18n.Message{ID: "greeting", Other: "مرحبا {{.name}}"})); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "مرحبا ⁨Alice⁩", before.T("greeting", "name", "Alice"))
+	}
+}
+
+func TestI18n_SetBidiIsolation(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.AddMessages("en",
+			Message(&i18n.Message{ID: "greeting", Other: "Hello, {{.name}}!"}),
+			Message(&i18n.Message{ID: "brand", Other: "Acme"}),
+		)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		err = i.AddMessages("ar",
+			Message(&i18n.Message{ID: "greeting", Other: "مرحبا {{.name}}!"}),
+			Message(&i18n.Message{ID: "cart", One: "{{.PluralCount}} عنصر", Other: "{{$n := .PluralCount}}{{if .PluralCount}}{{$n}} عناصر{{end}}"}),
+			Message(&i18n.Message{ID: "place", Other: "المركز {{.PluralCount}}"}),
+			Message(&i18n.Message{ID: "welcome", Other: "<b>{{.name}}</b> في {{t \"brand\"}}"}),
+		)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		ar := i.Locale("ar").(*I18n)
+		assert.Equal(t, "مرحبا Alice!", ar.T("greeting", "name", "Alice"))
+		i.SetBidiIsolation(true)
+		ar = i.Locale("ar").(*I18n)
+		assert.Equal(t, "Hello, Alice!", i.T("greeting", "name", "Alice"))
+		assert.Equal(t, "مرحبا ⁨Alice⁩!", ar.T("greeting", "name", "Alice"))
+		assert.Equal(t, "⁨1⁩ عنصر", ar.P("cart", 1))
+		assert.Equal(t, "⁨100⁩ عناصر", ar.P("cart", 100))
+		assert.Equal(t, "المركز ⁨2⁩", ar.PO("place", 2))
+		assert.Equal(t, []RichNode{
+			{Tag: "b", Children: []RichNode{{Text: "⁨Bob⁩"}}},
+			{Text: " في ⁨Acme⁩"},
+		}, ar.RichTree("welcome", "name", "Bob"))
+	}
+}
@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// messageCatalog keeps the messages added to the bundle, whose templates are not accessible from the bundle.
+type messageCatalog struct {
+	mu       sync.RWMutex
+	messages map[language.Tag]map[string]*i18n.Message
+}
+
+func newMessageCatalog() *messageCatalog {
+	return &messageCatalog{messages: make(map[language.Tag]map[string]*i18n.Message)}
+}
+
+func (c *messageCatalog) add(tag language.Tag, messages ...*i18n.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[tag] == nil {
+		c.messages[tag] = make(map[string]*i18n.Message)
+	}
+	for _, m := range messages {
+		c.messages[tag][m.ID] = m
+	}
+}
+
+func (c *messageCatalog) get(tag language.Tag, id string) (*i18n.Message, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, ok := c.messages[tag][id]
+	return m, ok
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// unmarshalFuncs are the unmarshal functions of the catalog formats, keyed by the file extension.
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"json": json.Unmarshal,
+	"yaml": yaml.Unmarshal,
+	"yml":  yaml.Unmarshal,
+	"toml": toml.Unmarshal,
+}
+
+// readCatalog reads the message file, the language tag is taken from the file name as [i18n.Bundle.LoadMessageFile] does.
+func readCatalog(path string) (*i18n.MessageFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return i18n.ParseMessageFileBytes(data, path, unmarshalFuncs)
+}
+
+// writeCatalog writes the messages to the file in the format of its extension.
+func writeCatalog(path string, messages []*i18n.Message) error {
+	data, err := marshalCatalog(formatOf(path), messages)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func formatOf(path string) string {
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
+
+// marshalCatalog encodes the messages sorted by id, a message having only the "other" form or no fields at all
+// is encoded as a string.
+func marshalCatalog(format string, messages []*i18n.Message) ([]byte, error) {
+	root := make(map[string]any, len(messages))
+	for _, m := range messages {
+		root[m.ID] = messageValue(m)
+	}
+	switch format {
+	case "json":
+		buf := new(bytes.Buffer)
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(root); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "yaml", "yml":
+		buf := new(bytes.Buffer)
+		encoder := yaml.NewEncoder(buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(root); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "toml":
+		buf := new(bytes.Buffer)
+		if err := toml.NewEncoder(buf).Encode(root); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, exception.New(fmt.Sprintf("unsupported catalog format %q", format))
+}
+
+func messageValue(m *i18n.Message) any {
+	fields := messageFields(m)
+	if len(fields) == 0 || len(fields) == 1 && fields["other"] != "" {
+		return m.Other
+	}
+	return fields
+}
+
+func messageFields(m *i18n.Message) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range [][2]string{
+		{"description", m.Description},
+		{"hash", m.Hash},
+		{"leftDelim", m.LeftDelim},
+		{"rightDelim", m.RightDelim},
+		{"zero", m.Zero},
+		{"one", m.One},
+		{"two", m.Two},
+		{"few", m.Few},
+		{"many", m.Many},
+		{"other", m.Other},
+	} {
+		if field[1] != "" {
+			fields[field[0]] = field[1]
+		}
+	}
+	return fields
+}
+
+// sortMessages sorts the messages by id.
+func sortMessages(messages []*i18n.Message) []*i18n.Message {
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+	return messages
+}
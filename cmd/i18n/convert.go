@@ -0,0 +1,280 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	gopi "github.com/gopi-frame/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// conversionFormat is a format of the convert command, the flags tell which fields of the messages it keeps.
+type conversionFormat struct {
+	ext    string
+	parser func(tag language.Tag) translator.Parser
+	export func(messagePack translator.MessagePack) ([]byte, error)
+
+	description bool
+	hash        bool
+	delims      bool
+	plural      bool
+	// pluralOnly formats drop the messages without plural forms.
+	pluralOnly bool
+	// singularOnly formats drop the plural messages.
+	singularOnly bool
+	// positional formats rename the template fields to positional arguments, {{.argn}} is kept as the n-th argument
+	// and {{.PluralCount}} as the quantity of the plural formats.
+	positional bool
+}
+
+// positionalField matches the {{.argn}} fields kept by the positional formats.
+var positionalField = regexp.MustCompile(`^arg[1-9][0-9]*$`)
+
+var conversionFormats = map[string]conversionFormat{
+	"json":        catalogFormat("json"),
+	"yaml":        catalogFormat("yaml"),
+	"toml":        catalogFormat("toml"),
+	"nested-json": {ext: "json", parser: gopi.NestedJSONParser, export: gopi.ExportNestedJSON, description: true, hash: true, delims: true, plural: true},
+	"nested-yaml": {ext: "yaml", parser: gopi.NestedYAMLParser, export: gopi.ExportNestedYAML, description: true, hash: true, delims: true, plural: true},
+	"i18next":     {ext: "json", parser: gopi.I18nextParser, export: gopi.ExportI18next, plural: true},
+	"android":     {ext: "xml", parser: gopi.AndroidParser, export: gopi.ExportAndroid, description: true, plural: true, positional: true},
+	"strings":     {ext: "strings", parser: gopi.AppleStringsParser, export: gopi.ExportAppleStrings, description: true, singularOnly: true, positional: true},
+	"stringsdict": {ext: "stringsdict", parser: gopi.AppleStringsdictParser, export: gopi.ExportAppleStringsdict, plural: true, pluralOnly: true, positional: true},
+	"properties":  {ext: "properties", parser: gopi.PropertiesParser, export: gopi.ExportProperties, description: true, positional: true},
+	"arb":         {ext: "arb", parser: gopi.ARBParser, export: gopi.ExportARB, description: true, plural: true},
+	"ftl":         {ext: "ftl", parser: gopi.FluentParser, description: true, plural: true},
+}
+
+// catalogFormat returns the format of the go-i18n message files with the given extension.
+func catalogFormat(ext string) conversionFormat {
+	return conversionFormat{
+		ext: ext,
+		parser: func(tag language.Tag) translator.Parser {
+			return gopi.ParserFunc(func(data []byte) (translator.MessagePack, error) {
+				// the language tag is given, the name only tells the format.
+				file, err := i18n.ParseMessageFileBytes(data, "messages."+ext, unmarshalFuncs)
+				if err != nil {
+					return nil, err
+				}
+				messages := make([]translator.Message, 0, len(file.Messages))
+				for _, m := range sortMessages(file.Messages) {
+					messages = append(messages, gopi.Message(m))
+				}
+				return gopi.MessagePack(messages, tag), nil
+			})
+		},
+		export: func(messagePack translator.MessagePack) ([]byte, error) {
+			messages := make([]*i18n.Message, 0, len(messagePack.GetMessages()))
+			for _, m := range messagePack.GetMessages() {
+				messages = append(messages, &i18n.Message{
+					ID:          m.GetID(),
+					Hash:        m.GetHash(),
+					Description: m.GetDescription(),
+					LeftDelim:   m.GetLeftDelim(),
+					RightDelim:  m.GetRightDelim(),
+					Zero:        m.GetZero(),
+					One:         m.GetOne(),
+					Two:         m.GetTwo(),
+					Few:         m.GetFew(),
+					Many:        m.GetMany(),
+					Other:       m.GetOther(),
+				})
+			}
+			return marshalCatalog(ext, sortMessages(messages))
+		},
+		description: true,
+		hash:        true,
+		delims:      true,
+		plural:      true,
+	}
+}
+
+func formatNames(readable bool) string {
+	var names []string
+	for name, format := range conversionFormats {
+		if readable || format.export != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func convertCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := flags.String("from", "", "format of the input files: "+formatNames(true))
+	to := flags.String("to", "", "format of the output files: "+formatNames(false))
+	lang := flags.String("lang", "", "language tag of the input files whose name has no {locale} part")
+	out := flags.String("out", "", "output file or directory, defaults to the input path with the extension of the output format")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: i18n convert -from <format> -to <format> [flags] <file or dir>")
+		fmt.Fprintln(flags.Output(), "\nConverts a message file, or the files of a directory recursively, from one format to another.")
+		fmt.Fprintln(flags.Output(), "The language tag is read from the {name}.{locale}.{ext} file name. A warning is printed for")
+		fmt.Fprintln(flags.Output(), "each message losing a field the output format cannot represent.")
+		fmt.Fprintln(flags.Output(), "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	fromFormat, ok := conversionFormats[*from]
+	if !ok {
+		return exception.New(fmt.Sprintf("unsupported input format %q, expected one of %s", *from, formatNames(true)))
+	}
+	toFormat, ok := conversionFormats[*to]
+	if !ok || toFormat.export == nil {
+		return exception.New(fmt.Sprintf("unsupported output format %q, expected one of %s", *to, formatNames(false)))
+	}
+	var fallback language.Tag
+	if *lang != "" {
+		tag, err := language.Parse(*lang)
+		if err != nil {
+			return err
+		}
+		fallback = tag
+	}
+	input := flags.Arg(0)
+	info, err := os.Stat(input)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		output := *out
+		if output == "" {
+			output = replaceExt(input, toFormat.ext)
+		}
+		return convertFile(input, output, fromFormat, toFormat, fallback, stdout)
+	}
+	outdir := *out
+	if outdir == "" {
+		outdir = input
+	}
+	return filepath.WalkDir(input, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || formatOf(path) != fromFormat.ext {
+			return err
+		}
+		rel, err := filepath.Rel(input, path)
+		if err != nil {
+			return err
+		}
+		return convertFile(path, filepath.Join(outdir, replaceExt(rel, toFormat.ext)), fromFormat, toFormat, fallback, stdout)
+	})
+}
+
+func convertFile(input, output string, from, to conversionFormat, fallback language.Tag, stdout io.Writer) error {
+	if filepath.Clean(input) == filepath.Clean(output) {
+		return exception.New(fmt.Sprintf("%s: the output would overwrite the input, set -out", input))
+	}
+	tag := fileLanguage(input, fallback)
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+	messagePack, err := from.parser(tag).Parse(data)
+	if err != nil {
+		return exception.New(fmt.Sprintf("%s: %s", input, err.Error()))
+	}
+	if messagePack.GetLanguageTag() == language.Und {
+		return exception.New(fmt.Sprintf("%s: unknown language, name the file {name}.{locale}.%s or set -lang", input, from.ext))
+	}
+	for _, warning := range conversionWarnings(messagePack.GetMessages(), to) {
+		fmt.Fprintf(stdout, "warning: %s: %s\n", input, warning)
+	}
+	content, err := to.export(messagePack)
+	if err != nil {
+		return exception.New(fmt.Sprintf("%s: %s", input, err.Error()))
+	}
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(output, content, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s -> %s\n", input, output)
+	return nil
+}
+
+// conversionWarnings returns the fields of the messages the format cannot represent.
+func conversionWarnings(messages []translator.Message, to conversionFormat) []string {
+	var warnings []string
+	for _, m := range messages {
+		plural := m.GetZero() != "" || m.GetOne() != "" || m.GetTwo() != "" || m.GetFew() != "" || m.GetMany() != ""
+		switch {
+		case plural && to.singularOnly:
+			warnings = append(warnings, fmt.Sprintf("%s: plural message is dropped", m.GetID()))
+			continue
+		case !plural && to.pluralOnly:
+			warnings = append(warnings, fmt.Sprintf("%s: message without plural forms is dropped", m.GetID()))
+			continue
+		case plural && !to.plural:
+			warnings = append(warnings, fmt.Sprintf("%s: only the other form is kept", m.GetID()))
+		}
+		if m.GetDescription() != "" && !to.description {
+			warnings = append(warnings, fmt.Sprintf("%s: description is dropped", m.GetID()))
+		}
+		if m.GetHash() != "" && !to.hash {
+			warnings = append(warnings, fmt.Sprintf("%s: hash is dropped", m.GetID()))
+		}
+		if (m.GetLeftDelim() != "" || m.GetRightDelim() != "") && !to.delims {
+			warnings = append(warnings, fmt.Sprintf("%s: custom delimiters are dropped", m.GetID()))
+		}
+		if to.positional {
+			if names := renamedFields(m, plural && to.plural); len(names) > 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: placeholders %s are renamed to positional arguments", m.GetID(), strings.Join(names, ", ")))
+			}
+		}
+	}
+	return warnings
+}
+
+// renamedFields returns the sorted template fields of the message which are not kept by the positional formats,
+// {{.PluralCount}} is kept by the plural messages.
+func renamedFields(m translator.Message, plural bool) []string {
+	renamed := map[string]bool{}
+	for _, text := range []string{m.GetZero(), m.GetOne(), m.GetTwo(), m.GetFew(), m.GetMany(), m.GetOther()} {
+		fields, _ := templateFields(text, m.GetLeftDelim(), m.GetRightDelim())
+		for _, field := range fields {
+			if positionalField.MatchString(field) || (plural && field == "PluralCount") {
+				continue
+			}
+			renamed[field] = true
+		}
+	}
+	names := make([]string, 0, len(renamed))
+	for name := range renamed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fileLanguage returns the language tag of the {name}.{locale}.{ext} file name, or the fallback.
+func fileLanguage(path string, fallback language.Tag) language.Tag {
+	parts := strings.Split(filepath.Base(path), ".")
+	if len(parts) >= 3 {
+		if tag, err := language.Parse(parts[len(parts)-2]); err == nil {
+			return tag
+		}
+	}
+	return fallback
+}
+
+func replaceExt(path, ext string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "." + ext
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertCommand(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "active.fr.toml")
+		if err := writeCatalog(input, []*i18n.Message{
+			{ID: "hello", Description: "Greeting", Hash: "sha1-1", Other: "Bonjour, {{.name}} !"},
+			{ID: "items", One: "{{.PluralCount}} article", Other: "{{.PluralCount}} articles"},
+		}); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		stdout := new(bytes.Buffer)
+		if err := convertCommand([]string{"-from", "toml", "-to", "arb", input}, stdout); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		output := filepath.Join(dir, "active.fr.arb")
+		assert.Equal(t, "warning: "+input+": hello: hash is dropped\n"+input+" -> "+output+"\n", stdout.String())
+		content, err := os.ReadFile(output)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.JSONEq(t, `{
+			"@@locale": "fr",
+			"hello": "Bonjour, {name} !",
+			"@hello": {"description": "Greeting", "placeholders": {"name": {}}},
+			"items": "{count, plural, one{# article} other{# articles}}",
+			"@items": {"placeholders": {"count": {}}}
+		}`, string(content))
+
+		// and back to a go-i18n catalog
+		stdout.Reset()
+		back := filepath.Join(dir, "back.fr.json")
+		if err := convertCommand([]string{"-from", "arb", "-to", "json", "-out", back, output}, stdout); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		file, err := readCatalog(back)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Len(t, file.Messages, 2)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		out := t.TempDir()
+		if err := writeCatalog(filepath.Join(dir, "active.en.json"), []*i18n.Message{
+			{ID: "hello", Other: "Hello"},
+			{ID: "items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"},
+		}); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if err := writeCatalog(filepath.Join(dir, "nested", "active.de.json"), []*i18n.Message{
+			{ID: "hello", Other: "Hallo"},
+		}); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		stdout := new(bytes.Buffer)
+		if err := convertCommand([]string{"-from", "json", "-to", "properties", "-out", out, dir}, stdout); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Contains(t, stdout.String(), "items: only the other form is kept")
+		assert.Contains(t, stdout.String(), "items: placeholders PluralCount are renamed to positional arguments")
+		content, err := os.ReadFile(filepath.Join(out, "active.en.properties"))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "hello=Hello\nitems={0} items\n", string(content))
+		content, err = os.ReadFile(filepath.Join(out, "nested", "active.de.properties"))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "hello=Hallo\n", string(content))
+	})
+
+	t.Run("positional placeholders", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "active.en.json")
+		if err := writeCatalog(input, []*i18n.Message{
+			{ID: "hello", Other: "Hello, {{.name}}! {{.arg2}} {{.name}}"},
+			{ID: "items", One: "{{.PluralCount}} item in {{.folder}}", Other: "{{.PluralCount}} items in {{.folder}}"},
+			{ID: "plain", Other: "{{.arg1}} and {{.arg2}}"},
+		}); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		stdout := new(bytes.Buffer)
+		if err := convertCommand([]string{"-from", "json", "-to", "android", input}, stdout); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Contains(t, stdout.String(), "hello: placeholders name are renamed to positional arguments")
+		assert.Contains(t, stdout.String(), "items: placeholders folder are renamed to positional arguments")
+		assert.NotContains(t, stdout.String(), "plain:")
+		assert.NotContains(t, stdout.String(), "PluralCount")
+		stdout.Reset()
+		if err := convertCommand([]string{"-from", "json", "-to", "arb", input}, stdout); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.NotContains(t, stdout.String(), "renamed")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dir := t.TempDir()
+		input := filepath.Join(dir, "strings.xml")
+		if err := os.WriteFile(input, []byte(`<resources><string name="hello">Hello</string></resources>`), 0o644); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		stdout := new(bytes.Buffer)
+		assert.ErrorContains(t, convertCommand([]string{"-from", "po", "-to", "json", input}, stdout), `unsupported input format "po"`)
+		assert.ErrorContains(t, convertCommand([]string{"-from", "android", "-to", "ftl", input}, stdout), `unsupported output format "ftl"`)
+		assert.ErrorContains(t, convertCommand([]string{"-from", "android", "-to", "json", input}, stdout), "unknown language")
+		assert.NoError(t, convertCommand([]string{"-from", "android", "-to", "json", "-lang", "en", input}, stdout))
+	})
+}
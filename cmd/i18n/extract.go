@@ -0,0 +1,442 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	goI18nPath     = "github.com/nicksnyder/go-i18n/v2/i18n"
+	i18nPath       = "github.com/gopi-frame/i18n"
+	translatorPath = "github.com/gopi-frame/contract/translator"
+)
+
+// descriptionMarker marks the comment above a T or P call as the description of the message.
+const descriptionMarker = "i18n:"
+
+func extractCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("extract", flag.ContinueOnError)
+	sourceLanguage := flags.String("sourceLanguage", "en", "language tag of the messages in the source files")
+	outdir := flags.String("outdir", ".", "directory of the source catalog")
+	format := flags.String("format", "json", "format of the source catalog, json, yaml or toml")
+	prune := flags.Bool("prune", false, "remove the messages which are not found in the source files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: i18n extract [flags] [paths]")
+		fmt.Fprintln(flags.Output(), "\nExtracts messages from the Go source files to active.{sourceLanguage}.{format},")
+		fmt.Fprintln(flags.Output(), "merging them with the existing catalog. Paths default to the current directory.")
+		fmt.Fprintln(flags.Output(), "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	extracted, err := extract(paths)
+	if err != nil {
+		return err
+	}
+	out := filepath.Join(*outdir, "active."+*sourceLanguage+"."+*format)
+	var existing []*i18n.Message
+	if file, err := readCatalog(out); err == nil {
+		existing = file.Messages
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	messages := mergeExtracted(existing, extracted, *prune)
+	if err := writeCatalog(out, messages); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "extracted %d messages to %s\n", len(extracted), out)
+	return nil
+}
+
+// mergeExtracted merges the extracted messages into the existing ones, the non-empty fields of the extracted messages
+// take precedence, the existing messages not extracted are kept unless prune is set.
+func mergeExtracted(existing, extracted []*i18n.Message, prune bool) []*i18n.Message {
+	merged := make(map[string]*i18n.Message)
+	for _, m := range existing {
+		merged[m.ID] = m
+	}
+	found := make(map[string]bool)
+	for _, e := range extracted {
+		found[e.ID] = true
+		m, ok := merged[e.ID]
+		if !ok {
+			merged[e.ID] = e
+			continue
+		}
+		for key, value := range messageFields(e) {
+			setField(m, key, value)
+		}
+	}
+	messages := make([]*i18n.Message, 0, len(merged))
+	for id, m := range merged {
+		if !prune || found[id] {
+			messages = append(messages, m)
+		}
+	}
+	return sortMessages(messages)
+}
+
+func setField(m *i18n.Message, key, value string) {
+	switch key {
+	case "description":
+		m.Description = value
+	case "hash":
+		m.Hash = value
+	case "leftDelim":
+		m.LeftDelim = value
+	case "rightDelim":
+		m.RightDelim = value
+	case "zero":
+		m.Zero = value
+	case "one":
+		m.One = value
+	case "two":
+		m.Two = value
+	case "few":
+		m.Few = value
+	case "many":
+		m.Many = value
+	case "other":
+		m.Other = value
+	}
+}
+
+// extract extracts the messages from the Go packages of the paths, directories are loaded recursively,
+// test files and the testdata, vendor and hidden directories are skipped, a trailing "/..." is accepted as well.
+// The packages are type-checked, only the calls on values implementing translator.Translator are extracted.
+func extract(paths []string) ([]*i18n.Message, error) {
+	e := &extractor{fset: token.NewFileSet(), messages: make(map[string]*i18n.Message)}
+	patterns := make([]string, 0, len(paths))
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		path = filepath.Clean(strings.TrimSuffix(path, "..."))
+		root, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+		if path = filepath.ToSlash(path); !filepath.IsAbs(path) && path != "." && path != ".." &&
+			!strings.HasPrefix(path, "../") {
+			path = "./" + path
+		}
+		patterns = append(patterns, path+"/...")
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Fset: e.fset,
+		// the dependencies are type-checked from source, only their declarations are needed.
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			file, err := parser.ParseFile(fset, filename, src, parser.AllErrors|parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil || inRoots(filename, roots) {
+				return file, err
+			}
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && (fn.Recv != nil || fn.Name.Name != "init") {
+					fn.Body = nil
+				}
+			}
+			return file, nil
+		},
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if e.translator == nil && pkg.Types != nil {
+			e.translator = translatorInterface(pkg.Types, make(map[*types.Package]bool))
+		}
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, exception.New(pkg.Errors[0].Error())
+		}
+		for _, file := range pkg.Syntax {
+			if err := e.extractFile(pkg.TypesInfo, file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	messages := make([]*i18n.Message, 0, len(e.messages))
+	for _, m := range e.messages {
+		messages = append(messages, m)
+	}
+	return sortMessages(messages), nil
+}
+
+type extractor struct {
+	fset       *token.FileSet
+	translator *types.Interface
+	messages   map[string]*i18n.Message
+}
+
+// fileContext holds the type information of the package and the descriptions of the file keyed by line.
+type fileContext struct {
+	info         *types.Info
+	descriptions map[int]string
+}
+
+func (e *extractor) extractFile(info *types.Info, file *ast.File) error {
+	ctx := &fileContext{info: info, descriptions: make(map[int]string)}
+	for _, group := range file.Comments {
+		text := strings.TrimSpace(group.Text())
+		if strings.HasPrefix(text, descriptionMarker) {
+			ctx.descriptions[e.fset.Position(group.End()).Line+1] = strings.TrimSpace(strings.TrimPrefix(text, descriptionMarker))
+		}
+	}
+	var inspectErr error
+	ast.Inspect(file, func(node ast.Node) bool {
+		if inspectErr != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.CompositeLit:
+			inspectErr = e.extractMessageLiteral(ctx, n)
+		case *ast.CallExpr:
+			inspectErr = e.extractCall(ctx, n)
+		}
+		return inspectErr == nil
+	})
+	if inspectErr != nil {
+		return exception.New(fmt.Sprintf("%s: %s", e.fset.Position(file.Pos()).Filename, inspectErr.Error()))
+	}
+	return nil
+}
+
+// extractMessageLiteral extracts the go-i18n Message literals having a constant ID.
+func (e *extractor) extractMessageLiteral(ctx *fileContext, lit *ast.CompositeLit) error {
+	if !isNamed(ctx.info.TypeOf(lit), goI18nPath, "Message") {
+		return nil
+	}
+	m := &i18n.Message{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		value, ok := stringConst(kv.Value)
+		if !ok {
+			continue
+		}
+		if key.Name == "ID" {
+			m.ID = value
+		} else {
+			setField(m, strings.ToLower(key.Name[:1])+key.Name[1:], value)
+		}
+	}
+	if m.ID == "" {
+		return nil
+	}
+	return e.add(lit.Pos(), m)
+}
+
+// extractCall extracts the message ids of the T and P method calls on translators, and the default messages set by
+// SetDefaultMessage and SetDefaultMessages. The prefix of a translator returned by a Scope call with
+// a constant namespace is added to the id.
+func (e *extractor) extractCall(ctx *fileContext, call *ast.CallExpr) error {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if packageOf(ctx.info, sel.X) == i18nPath {
+		// package level functions of the i18n package.
+		switch sel.Sel.Name {
+		case "SetDefaultMessage":
+			if len(call.Args) == 2 {
+				id, ok1 := stringConst(call.Args[0])
+				other, ok2 := stringConst(call.Args[1])
+				if ok1 && ok2 {
+					return e.add(call.Pos(), &i18n.Message{ID: id, Other: other})
+				}
+			}
+		case "SetDefaultMessages":
+			if len(call.Args) == 1 {
+				if lit, ok := call.Args[0].(*ast.CompositeLit); ok {
+					for _, elt := range lit.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						id, ok1 := stringConst(kv.Key)
+						other, ok2 := stringConst(kv.Value)
+						if ok1 && ok2 {
+							if err := e.add(kv.Pos(), &i18n.Message{ID: id, Other: other}); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+		return nil
+	}
+	if (sel.Sel.Name != "T" || len(call.Args) < 1) && (sel.Sel.Name != "P" || len(call.Args) < 2) {
+		return nil
+	}
+	if !e.isTranslator(ctx.info, sel) {
+		return nil
+	}
+	id, ok := stringConst(call.Args[0])
+	if !ok {
+		return nil
+	}
+	m := &i18n.Message{ID: e.scopePrefix(ctx.info, sel.X) + id}
+	m.Description = ctx.descriptions[e.fset.Position(call.Pos()).Line]
+	return e.add(call.Pos(), m)
+}
+
+// inRoots reports whether the file is in one of the root directories.
+func inRoots(filename string, roots []string) bool {
+	for _, root := range roots {
+		if rel, err := filepath.Rel(root, filename); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// translatorInterface returns the translator.Translator interface if the package depends on it.
+func translatorInterface(pkg *types.Package, seen map[*types.Package]bool) *types.Interface {
+	if seen[pkg] {
+		return nil
+	}
+	seen[pkg] = true
+	if pkg.Path() == translatorPath {
+		if obj := pkg.Scope().Lookup("Translator"); obj != nil {
+			iface, _ := obj.Type().Underlying().(*types.Interface)
+			return iface
+		}
+		return nil
+	}
+	for _, imported := range pkg.Imports() {
+		if iface := translatorInterface(imported, seen); iface != nil {
+			return iface
+		}
+	}
+	return nil
+}
+
+// isTranslator reports whether the selector is a method of a value implementing translator.Translator.
+func (e *extractor) isTranslator(info *types.Info, sel *ast.SelectorExpr) bool {
+	if e.translator == nil {
+		return false
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	recv := selection.Recv()
+	if types.Implements(recv, e.translator) {
+		return true
+	}
+	if _, ok := recv.Underlying().(*types.Pointer); ok || types.IsInterface(recv) {
+		return false
+	}
+	return types.Implements(types.NewPointer(recv), e.translator)
+}
+
+// scopePrefix returns the prefix of the translator returned by nested Scope calls with constant namespaces.
+func (e *extractor) scopePrefix(info *types.Info, x ast.Expr) string {
+	call, ok := x.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Scope" || !e.isTranslator(info, sel) {
+		return ""
+	}
+	namespace, ok := stringConst(call.Args[0])
+	if !ok {
+		return ""
+	}
+	return e.scopePrefix(info, sel.X) + namespace + "."
+}
+
+// add adds the message, the fields of the messages with the same id are merged, conflicting fields are reported.
+func (e *extractor) add(pos token.Pos, m *i18n.Message) error {
+	existing, ok := e.messages[m.ID]
+	if !ok {
+		e.messages[m.ID] = m
+		return nil
+	}
+	existingFields := messageFields(existing)
+	for key, value := range messageFields(m) {
+		if current, ok := existingFields[key]; ok && current != value {
+			return exception.New(fmt.Sprintf("%s: conflicting %s of message %q: %q and %q", e.fset.Position(pos), key, m.ID, current, value))
+		}
+		setField(existing, key, value)
+	}
+	return nil
+}
+
+// isNamed reports whether t is the named type, or a pointer to it, of the package with the given path.
+func isNamed(t types.Type, pkgPath, name string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// packageOf returns the path of the package an identifier refers to, or an empty string.
+func packageOf(info *types.Info, expr ast.Expr) string {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+		return pkgName.Imported().Path()
+	}
+	return ""
+}
+
+// stringConst returns the value of a string literal or a concatenation of string literals.
+func stringConst(expr ast.Expr) (string, bool) {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(v.Value)
+		return s, err == nil
+	case *ast.BinaryExpr:
+		if v.Op != token.ADD {
+			return "", false
+		}
+		x, ok := stringConst(v.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := stringConst(v.Y)
+		return x + y, ok
+	case *ast.ParenExpr:
+		return stringConst(v.X)
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	messages, err := extract([]string{"testdata/extract"})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, []*i18n.Message{
+		{ID: "billing.total"},
+		{ID: "cart.items"},
+		{ID: "goodbye", Other: "Goodbye"},
+		{ID: "inbox", Description: "Unread emails", One: "{{.PluralCount}} email", Other: "{{.PluralCount}} emails"},
+		{ID: "welcome", Description: "Greeting on the home page.", Other: "Welcome, {{.name}}!"},
+	}, messages)
+}
+
+func TestExtractCommand(t *testing.T) {
+	for _, format := range []string{"json", "yaml", "toml"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			out := filepath.Join(dir, "active.en."+format)
+			if err := writeCatalog(out, []*i18n.Message{
+				{ID: "removed", Other: "Removed"},
+				{ID: "goodbye", Description: "Farewell", Other: "Bye"},
+			}); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			stdout := new(bytes.Buffer)
+			if err := extractCommand([]string{"-outdir", dir, "-format", format, "testdata/extract"}, stdout); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			file, err := readCatalog(out)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			ids := make(map[string]*i18n.Message)
+			for _, m := range file.Messages {
+				ids[m.ID] = m
+			}
+			assert.Len(t, ids, 6)
+			assert.Equal(t, "Removed", ids["removed"].Other)
+			assert.Equal(t, "Farewell", ids["goodbye"].Description)
+			assert.Equal(t, "Goodbye", ids["goodbye"].Other)
+			assert.Equal(t, "{{.PluralCount}} email", ids["inbox"].One)
+
+			if err := extractCommand([]string{"-outdir", dir, "-format", format, "-prune", "testdata/extract"}, stdout); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			content, err := os.ReadFile(out)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.NotContains(t, string(content), "removed")
+		})
+	}
+}
+
+func TestExtract_Conflict(t *testing.T) {
+	_, err := extract([]string{"testdata/conflict"})
+	assert.ErrorContains(t, err, `conflicting other of message "hello"`)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func generateCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	out := flags.String("out", "messages.go", "output Go file")
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "package name of the output file, defaults to $GOPACKAGE or the name of the output directory")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: i18n generate [flags] <source catalog>")
+		fmt.Fprintln(flags.Output(), "\nGenerates an id constant and a typed accessor for each message of the source catalog,")
+		fmt.Fprintln(flags.Output(), "with a string parameter for each template field and an int count parameter for plural messages.")
+		fmt.Fprintln(flags.Output(), "\n//go:generate go run github.com/gopi-frame/i18n/cmd/i18n generate -out messages.go ../locales/active.en.json")
+		fmt.Fprintln(flags.Output(), "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	file, err := readCatalog(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *pkg == "" {
+		abs, err := filepath.Abs(*out)
+		if err != nil {
+			return err
+		}
+		*pkg = filepath.Base(filepath.Dir(abs))
+	}
+	content, err := generate(*pkg, filepath.Base(flags.Arg(0)), file.Messages)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, content, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "generated %d messages to %s\n", len(file.Messages), *out)
+	return nil
+}
+
+// generate returns the source of the package with the id constants and accessors of the messages.
+func generate(pkg, source string, messages []*i18n.Message) ([]byte, error) {
+	messages = sortMessages(messages)
+	names := make(map[string]string)
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by i18n generate from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"github.com/gopi-frame/contract/translator\"\n\n")
+	buf.WriteString("// Message ids.\nconst (\n")
+	for _, m := range messages {
+		name := exportedName(m.ID)
+		if id, ok := names[name]; ok {
+			return nil, exception.New(fmt.Sprintf("messages %q and %q have the same name %s", id, m.ID, name))
+		}
+		names[name] = m.ID
+		fmt.Fprintf(buf, "\t%sID = %s\n", name, strconv.Quote(m.ID))
+	}
+	buf.WriteString(")\n")
+	for _, m := range messages {
+		name := exportedName(m.ID)
+		forms := messageForms(m)
+		plural := len(forms) > 1 || len(forms) == 1 && forms[0][0] != "other"
+		var fields []string
+		seen := make(map[string]bool)
+		for _, form := range forms {
+			formFields, err := templateFields(form[1], m.LeftDelim, m.RightDelim)
+			if err != nil {
+				return nil, exception.New(fmt.Sprintf("message %q: %s", m.ID, err.Error()))
+			}
+			for _, field := range formFields {
+				if !seen[field] {
+					seen[field] = true
+					fields = append(fields, field)
+				}
+			}
+		}
+		params := []string{"t translator.Translator"}
+		var call string
+		if plural {
+			params = append(params, "count int")
+			call = "t.P(" + name + "ID, count"
+		} else {
+			call = "t.T(" + name + "ID"
+		}
+		var data []string
+		used := map[string]bool{"t": true, "count": true}
+		for _, field := range fields {
+			if field == "PluralCount" && plural {
+				continue
+			}
+			param := paramName(field)
+			for n := 2; used[param]; n++ {
+				param = paramName(field) + strconv.Itoa(n)
+			}
+			used[param] = true
+			params = append(params, param+" string")
+			data = append(data, strconv.Quote(field), param)
+		}
+		if len(data) > 0 {
+			call += ", " + strings.Join(data, ", ")
+		}
+		call += ")"
+		buf.WriteString("\n")
+		writeDocComment(buf, name, m)
+		fmt.Fprintf(buf, "func %s(%s) string {\n\treturn %s\n}\n", name, strings.Join(params, ", "), call)
+	}
+	return format.Source(buf.Bytes())
+}
+
+func writeDocComment(buf *bytes.Buffer, name string, m *i18n.Message) {
+	fmt.Fprintf(buf, "// %s translates the message %q.\n", name, m.ID)
+	if m.Description != "" {
+		buf.WriteString("//\n")
+		for _, line := range strings.Split(m.Description, "\n") {
+			buf.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+		}
+	}
+	buf.WriteString("//\n")
+	for _, form := range messageForms(m) {
+		buf.WriteString("//\t" + form[0] + ": " + strings.ReplaceAll(form[1], "\n", `\n`) + "\n")
+	}
+}
+
+// exportedName converts the message id to an exported Go name, "cart.item_count" is converted to CartItemCount.
+func exportedName(id string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range id {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteString("M")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "M"
+	}
+	return sb.String()
+}
+
+// paramName converts the template field to a parameter name, which does not conflict with keywords and the
+// parameters t and count.
+func paramName(field string) string {
+	name := []rune(exportedName(field))
+	name[0] = unicode.ToLower(name[0])
+	param := string(name)
+	if token.IsKeyword(param) || param == "t" || param == "count" {
+		param += "Value"
+	}
+	return param
+}
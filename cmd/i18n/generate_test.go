@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	file, err := readCatalog("testdata/generate/active.en.json")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	content, err := generate("msgs", "active.en.json", file.Messages)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	golden, err := os.ReadFile("testdata/generate/messages.go.golden")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, string(golden), string(content))
+}
+
+func TestExportedName(t *testing.T) {
+	assert.Equal(t, "CartItemCount", exportedName("cart.item_count"))
+	assert.Equal(t, "HelloWorld", exportedName("hello-world"))
+	assert.Equal(t, "M404Title", exportedName("404.title"))
+	assert.Equal(t, "typeValue", paramName("type"))
+	assert.Equal(t, "userName", paramName("user_name"))
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"github.com/gopi-frame/exception"
+	gopi "github.com/gopi-frame/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// lint rules
+const (
+	ruleTemplate     = "template"
+	rulePlaceholders = "placeholders"
+	rulePluralForms  = "plural-forms"
+	ruleDuplicate    = "duplicate"
+	ruleEmpty        = "empty"
+	ruleUntranslated = "untranslated"
+	ruleWhitespace   = "whitespace"
+	ruleReference    = "reference"
+)
+
+type lintIssue struct {
+	File    string `json:"file"`
+	Locale  string `json:"locale"`
+	ID      string `json:"id"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func lintCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	sourceLanguage := flags.String("sourceLanguage", "en", "language tag of the source catalog")
+	jsonOutput := flags.Bool("json", false, "report the issues as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: i18n lint [flags] [dir]")
+		fmt.Fprintln(flags.Output(), "\nChecks the {name}.{locale}.{format} files of the directory recursively, the translate.* and")
+		fmt.Fprintln(flags.Output(), "archive.* files of the merge command are skipped. Exits with status 1 if any issue is found.")
+		fmt.Fprintln(flags.Output(), "The directory defaults to the current directory.")
+		fmt.Fprintln(flags.Output(), "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := flags.Arg(0)
+	if dir == "" {
+		dir = "."
+	}
+	sourceTag, err := language.Parse(*sourceLanguage)
+	if err != nil {
+		return err
+	}
+	files, err := findMessageFiles(dir)
+	if err != nil {
+		return err
+	}
+	issues := lint(files, sourceTag)
+	if *jsonOutput {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if issues == nil {
+			issues = []lintIssue{}
+		}
+		if err := encoder.Encode(issues); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range issues {
+			fmt.Fprintf(stdout, "%s: %s: %s: %s\n", issue.File, issue.ID, issue.Rule, issue.Message)
+		}
+	}
+	if len(issues) > 0 {
+		return exception.New(fmt.Sprintf("found %d issues", len(issues)))
+	}
+	return nil
+}
+
+// findMessageFiles parses the message files of the directory recursively.
+func findMessageFiles(dir string) ([]*i18n.MessageFile, error) {
+	var files []*i18n.MessageFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name := d.Name()
+		if unmarshalFuncs[formatOf(name)] == nil || strings.HasPrefix(name, translateKind+".") || strings.HasPrefix(name, archiveKind+".") {
+			return nil
+		}
+		file, err := readCatalog(path)
+		if err != nil {
+			return exception.New(fmt.Sprintf("%s: %s", path, err.Error()))
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+// lint checks the message files against the messages of the source language.
+func lint(files []*i18n.MessageFile, sourceTag language.Tag) []lintIssue {
+	var issues []lintIssue
+	report := func(file *i18n.MessageFile, id, rule, format string, args ...any) {
+		issues = append(issues, lintIssue{
+			File:    file.Path,
+			Locale:  file.Tag.String(),
+			ID:      id,
+			Rule:    rule,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	sources := make(map[string]*i18n.Message)
+	ids := make(map[language.Tag]map[string]bool)
+	for _, file := range files {
+		if ids[file.Tag] == nil {
+			ids[file.Tag] = make(map[string]bool)
+		}
+		for _, m := range file.Messages {
+			ids[file.Tag][m.ID] = true
+			if file.Tag == sourceTag {
+				sources[m.ID] = m
+			}
+		}
+	}
+	seen := make(map[string]string)
+	for _, file := range files {
+		for _, m := range file.Messages {
+			key := file.Tag.String() + "\x00" + m.ID
+			if path, ok := seen[key]; ok {
+				report(file, m.ID, ruleDuplicate, "also defined in %s", path)
+			} else {
+				seen[key] = file.Path
+			}
+			forms := messageForms(m)
+			if len(forms) == 0 {
+				report(file, m.ID, ruleEmpty, "empty message")
+			} else if m.Other == "" {
+				report(file, m.ID, ruleEmpty, "empty \"other\" form")
+			}
+			var names []string
+			fields := make(map[string]bool)
+			for _, form := range forms {
+				if strings.TrimSpace(form[1]) != form[1] {
+					report(file, m.ID, ruleWhitespace, "leading or trailing whitespace in %q form", form[0])
+				}
+				formFields, err := templateFields(form[1], m.LeftDelim, m.RightDelim)
+				if err != nil {
+					report(file, m.ID, ruleTemplate, "%q form: %s", form[0], err.Error())
+					continue
+				}
+				for _, name := range formFields {
+					fields[name] = true
+				}
+				names = append(names, form[0])
+				refs, _ := templateReferences(form[1], m.LeftDelim, m.RightDelim)
+				for _, ref := range refs {
+					// the message of the source language is used if the language has no such message.
+					if !ids[file.Tag][ref] && sources[ref] == nil {
+						report(file, m.ID, ruleReference, "%q form: reference to unknown message %q", form[0], ref)
+					}
+				}
+			}
+			source, ok := sources[m.ID]
+			if !ok || file.Tag == sourceTag {
+				continue
+			}
+			if len(forms) > 0 && sameText(m, source) {
+				report(file, m.ID, ruleUntranslated, "same text as the source language")
+			}
+			sourceFields := make(map[string]bool)
+			for _, form := range messageForms(source) {
+				formFields, _ := templateFields(form[1], source.LeftDelim, source.RightDelim)
+				for _, name := range formFields {
+					sourceFields[name] = true
+				}
+			}
+			if missing := difference(sourceFields, fields); len(missing) > 0 {
+				report(file, m.ID, rulePlaceholders, "missing placeholders %s", strings.Join(missing, ", "))
+			}
+			if extra := difference(fields, sourceFields); len(extra) > 0 {
+				report(file, m.ID, rulePlaceholders, "unknown placeholders %s", strings.Join(extra, ", "))
+			}
+			if len(messageForms(source)) > 1 || len(forms) > 1 {
+				var missing []string
+				for _, category := range gopi.PluralCategories(plural.Cardinal, file.Tag) {
+					if !slices.Contains(names, category) {
+						missing = append(missing, category)
+					}
+				}
+				if len(missing) > 0 {
+					report(file, m.ID, rulePluralForms, "missing plural forms %s", strings.Join(missing, ", "))
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// messageForms returns the non-empty plural forms of the message.
+func messageForms(m *i18n.Message) [][2]string {
+	var forms [][2]string
+	for _, form := range [][2]string{
+		{"zero", m.Zero},
+		{"one", m.One},
+		{"two", m.Two},
+		{"few", m.Few},
+		{"many", m.Many},
+		{"other", m.Other},
+	} {
+		if form[1] != "" {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// templateFields parses the template and returns the names of the fields of the data it refers to.
+func templateFields(text, leftDelim, rightDelim string) ([]string, error) {
+	var fields []string
+	err := walkTemplate(text, leftDelim, rightDelim, func(node parse.Node) {
+		if field, ok := node.(*parse.FieldNode); ok {
+			fields = append(fields, field.Ident[0])
+		}
+	})
+	return fields, err
+}
+
+// templateReferences parses the template and returns the ids of the messages referenced by {{t "id"}}.
+func templateReferences(text, leftDelim, rightDelim string) ([]string, error) {
+	var refs []string
+	err := walkTemplate(text, leftDelim, rightDelim, func(node parse.Node) {
+		cmd, ok := node.(*parse.CommandNode)
+		if !ok || len(cmd.Args) < 2 {
+			return
+		}
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "t" {
+			if id, ok := cmd.Args[1].(*parse.StringNode); ok {
+				refs = append(refs, id.Text)
+			}
+		}
+	})
+	return refs, err
+}
+
+// walkTemplate parses the template and calls visit for each node.
+// Functions are not checked since they are provided when the message is rendered.
+func walkTemplate(text, leftDelim, rightDelim string, visit func(node parse.Node)) error {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, leftDelim, rightDelim, make(map[string]*parse.Tree)); err != nil {
+		return err
+	}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		visit(node)
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n != nil {
+				for _, cmd := range n.Cmds {
+					walk(cmd)
+				}
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.ChainNode:
+			walk(n.Node)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(tree.Root)
+	return nil
+}
+
+// difference returns the sorted keys of a which are not in b.
+func difference(a, b map[string]bool) []string {
+	var keys []string
+	for key := range a {
+		if !b[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
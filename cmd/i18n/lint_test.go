@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLint(t *testing.T) {
+	files, err := findMessageFiles("testdata/lint")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	en := filepath.Join("testdata", "lint", "active.en.json")
+	ru := filepath.Join("testdata", "lint", "active.ru.json")
+	extra := filepath.Join("testdata", "lint", "nested", "extra.ru.json")
+	assert.ElementsMatch(t, []lintIssue{
+		{File: en, Locale: "en", ID: "cats", Rule: ruleEmpty, Message: `empty "other" form`},
+		{File: en, Locale: "en", ID: "trailing", Rule: ruleWhitespace, Message: `leading or trailing whitespace in "other" form`},
+		{File: ru, Locale: "ru", ID: "hello", Rule: rulePlaceholders, Message: "missing placeholders name"},
+		{File: ru, Locale: "ru", ID: "hello", Rule: rulePlaceholders, Message: "unknown placeholders nam"},
+		{File: ru, Locale: "ru", ID: "items", Rule: rulePluralForms, Message: "missing plural forms few, many"},
+		{File: ru, Locale: "ru", ID: "ok", Rule: ruleUntranslated, Message: "same text as the source language"},
+		{File: ru, Locale: "ru", ID: "empty", Rule: ruleEmpty, Message: "empty message"},
+		{File: ru, Locale: "ru", ID: "broken", Rule: ruleTemplate, Message: `"other" form: template: message:1: unclosed action`},
+		{File: ru, Locale: "ru", ID: "welcome", Rule: ruleReference, Message: `"other" form: reference to unknown message "brnd"`},
+		{File: extra, Locale: "ru", ID: "ok", Rule: ruleDuplicate, Message: "also defined in " + ru},
+	}, lint(files, language.English))
+}
+
+func TestLintCommand(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		stdout := new(bytes.Buffer)
+		err := lintCommand([]string{"-json", "testdata/lint"}, stdout)
+		assert.ErrorContains(t, err, "found 10 issues")
+		var issues []lintIssue
+		if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Len(t, issues, 10)
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		files, err := findMessageFiles("testdata/lint-clean")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Len(t, files, 3)
+		stdout := new(bytes.Buffer)
+		assert.NoError(t, lintCommand([]string{"-json", "testdata/lint-clean"}, stdout))
+		assert.Equal(t, "[]\n", stdout.String())
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		assert.Equal(t, 1, run([]string{"lint", "testdata/lint"}, stdout, stderr))
+		assert.Equal(t, "i18n lint: found 10 issues\n", stderr.String())
+	})
+}
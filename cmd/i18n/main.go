@@ -0,0 +1,68 @@
+// Command i18n manages the message catalogs of the i18n package.
+//
+// Usage:
+//
+//	i18n <command> [flags] [arguments]
+//
+// Run "i18n <command> -h" for the flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"extract":  {usage: "extract messages from Go source files to a source catalog", run: extractCommand},
+	"merge":    {usage: "merge the source catalog with the catalogs of each locale", run: mergeCommand},
+	"lint":     {usage: "check the catalogs of a directory", run: lintCommand},
+	"convert":  {usage: "convert message files between formats", run: convertCommand},
+	"generate": {usage: "generate typed accessors of the messages of a source catalog", run: generateCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		usage(stderr)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "i18n: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	if err := cmd.run(args[1:], stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "i18n %s: %s\n", args[0], err.Error())
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: i18n <command> [flags] [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"crypto/sha1"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// catalog file kinds, the translations are merged from the active and translate files.
+const (
+	activeKind    = "active"
+	translateKind = "translate"
+	archiveKind   = "archive"
+)
+
+func mergeCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	sourceLanguage := flags.String("sourceLanguage", "en", "language tag of the source catalog")
+	outdir := flags.String("outdir", "", "directory of the merged catalogs, defaults to the input directory")
+	format := flags.String("format", "", "format of the merged catalogs, defaults to the format of the source catalog")
+	locales := flags.String("locales", "", "comma separated language tags of the locales to add")
+	archive := flags.Bool("archive", false, "move the removed messages to archive.{locale}.{format} instead of dropping them")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: i18n merge [flags] [dir]")
+		fmt.Fprintln(flags.Output(), "\nMerges the source catalog active.{sourceLanguage}.{format} with the active.{locale}.{format}")
+		fmt.Fprintln(flags.Output(), "and translate.{locale}.{format} files of the directory. Current translations are written to")
+		fmt.Fprintln(flags.Output(), "active.{locale}.{format}, the untranslated messages and the messages whose source changed")
+		fmt.Fprintln(flags.Output(), "to translate.{locale}.{format}. The directory defaults to the current directory.")
+		fmt.Fprintln(flags.Output(), "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := flags.Arg(0)
+	if dir == "" {
+		dir = "."
+	}
+	if *outdir == "" {
+		*outdir = dir
+	}
+	files, err := findCatalogs(dir)
+	if err != nil {
+		return err
+	}
+	source, ok := files[*sourceLanguage][activeKind]
+	if !ok {
+		return exception.New(fmt.Sprintf("missing source catalog active.%s.{format} in %s", *sourceLanguage, dir))
+	}
+	sourceFile, err := readCatalog(source)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = formatOf(source)
+	}
+	if *locales != "" {
+		for _, locale := range strings.Split(*locales, ",") {
+			tag, err := language.Parse(strings.TrimSpace(locale))
+			if err != nil {
+				return err
+			}
+			if _, ok := files[tag.String()]; !ok {
+				files[tag.String()] = make(map[string]string)
+			}
+		}
+	}
+	tags := make([]string, 0, len(files))
+	for tag := range files {
+		if tag != *sourceLanguage {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		var translations []*i18n.Message
+		for _, kind := range []string{activeKind, translateKind} {
+			if path, ok := files[tag][kind]; ok {
+				file, err := readCatalog(path)
+				if err != nil {
+					return err
+				}
+				translations = append(translations, file.Messages...)
+			}
+		}
+		result := mergeTranslations(sourceFile.Messages, translations)
+		if err := writeCatalog(filepath.Join(*outdir, activeKind+"."+tag+"."+*format), result.active); err != nil {
+			return err
+		}
+		translatePath := filepath.Join(*outdir, translateKind+"."+tag+"."+*format)
+		if len(result.translate) > 0 {
+			if err := writeCatalog(translatePath, result.translate); err != nil {
+				return err
+			}
+		} else if err := os.Remove(translatePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if *archive && len(result.removed) > 0 {
+			archivePath := filepath.Join(*outdir, archiveKind+"."+tag+"."+*format)
+			archived := result.removed
+			if path, ok := files[tag][archiveKind]; ok {
+				file, err := readCatalog(path)
+				if err != nil {
+					return err
+				}
+				archived = mergeArchived(file.Messages, archived)
+			}
+			if err := writeCatalog(archivePath, archived); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(stdout, "%s: %d translated, %d to translate, %d removed\n", tag, len(result.active), len(result.translate), len(result.removed))
+	}
+	return nil
+}
+
+// findCatalogs finds the {kind}.{locale}.{format} files of the directory, keyed by the locale and the kind.
+func findCatalogs(dir string) (map[string]map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		parts := strings.Split(entry.Name(), ".")
+		if len(parts) != 3 || unmarshalFuncs[parts[2]] == nil {
+			continue
+		}
+		if parts[0] != activeKind && parts[0] != translateKind && parts[0] != archiveKind {
+			continue
+		}
+		tag, err := language.Parse(parts[1])
+		if err != nil {
+			continue
+		}
+		if files[tag.String()] == nil {
+			files[tag.String()] = make(map[string]string)
+		}
+		files[tag.String()][parts[0]] = filepath.Join(dir, entry.Name())
+	}
+	return files, nil
+}
+
+type mergeResult struct {
+	active    []*i18n.Message
+	translate []*i18n.Message
+	removed   []*i18n.Message
+}
+
+// mergeTranslations merges the translations with the source messages.
+//
+// A translation is current if its hash equals the hash of the source message, a translation without hash is
+// considered current and the hash is added. The translation of a message whose source changed is kept in the
+// active messages until it is translated again, and the message is added to the messages to translate together
+// with the untranslated ones, which have the source text and the source hash. A translation equal to the source
+// text is considered untranslated. The translations of the messages removed from the source are returned as removed.
+func mergeTranslations(source, translations []*i18n.Message) mergeResult {
+	sources := make(map[string]*i18n.Message, len(source))
+	for _, m := range source {
+		sources[m.ID] = m
+	}
+	// the translations of the translate files come last and replace the stale active ones.
+	translated := make(map[string]*i18n.Message)
+	removed := make(map[string]*i18n.Message)
+	var result mergeResult
+	for _, m := range translations {
+		s, ok := sources[m.ID]
+		if !ok {
+			removed[m.ID] = m
+			continue
+		}
+		if sameText(m, s) {
+			continue
+		}
+		if current, ok := translated[m.ID]; ok && current.Hash == sourceHash(s) && m.Hash != sourceHash(s) {
+			continue
+		}
+		translated[m.ID] = m
+	}
+	for _, s := range source {
+		hash := sourceHash(s)
+		m, ok := translated[s.ID]
+		if ok && m.Hash == "" {
+			m.Hash = hash
+		}
+		if ok {
+			result.active = append(result.active, m)
+		}
+		if !ok || m.Hash != hash {
+			stub := *s
+			stub.Hash = hash
+			result.translate = append(result.translate, &stub)
+		}
+	}
+	for _, m := range removed {
+		result.removed = append(result.removed, m)
+	}
+	sortMessages(result.active)
+	sortMessages(result.translate)
+	sortMessages(result.removed)
+	return result
+}
+
+// mergeArchived adds the removed messages to the archived ones, the removed messages replace the archived ones with the same id.
+func mergeArchived(archived, removed []*i18n.Message) []*i18n.Message {
+	messages := make(map[string]*i18n.Message)
+	for _, m := range archived {
+		messages[m.ID] = m
+	}
+	for _, m := range removed {
+		messages[m.ID] = m
+	}
+	result := make([]*i18n.Message, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, m)
+	}
+	return sortMessages(result)
+}
+
+// sourceHash returns the hash of the description and the plural forms of the source message.
+func sourceHash(m *i18n.Message) string {
+	h := sha1.New()
+	for _, s := range []string{m.Description, m.LeftDelim, m.RightDelim, m.Zero, m.One, m.Two, m.Few, m.Many, m.Other} {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("sha1-%x", h.Sum(nil))
+}
+
+// sameText reports whether the translation has the same plural forms as the source message.
+func sameText(translation, source *i18n.Message) bool {
+	return translation.Zero == source.Zero && translation.One == source.One && translation.Two == source.Two &&
+		translation.Few == source.Few && translation.Many == source.Many && translation.Other == source.Other
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTranslations(t *testing.T) {
+	source := []*i18n.Message{
+		{ID: "changed", Other: "Hello, {{.name}}!"},
+		{ID: "current", Other: "Goodbye"},
+		{ID: "new", Description: "New message", Other: "New"},
+		{ID: "unhashed", Other: "Welcome"},
+	}
+	result := mergeTranslations(source, []*i18n.Message{
+		{ID: "changed", Hash: "sha1-old", Other: "Bonjour"},
+		{ID: "current", Hash: sourceHash(source[1]), Other: "Au revoir"},
+		{ID: "unhashed", Other: "Bienvenue"},
+		{ID: "removed", Other: "Supprimé"},
+	})
+	assert.Equal(t, []*i18n.Message{
+		{ID: "changed", Hash: "sha1-old", Other: "Bonjour"},
+		{ID: "current", Hash: sourceHash(source[1]), Other: "Au revoir"},
+		{ID: "unhashed", Hash: sourceHash(source[3]), Other: "Bienvenue"},
+	}, result.active)
+	assert.Equal(t, []*i18n.Message{
+		{ID: "changed", Hash: sourceHash(source[0]), Other: "Hello, {{.name}}!"},
+		{ID: "new", Description: "New message", Hash: sourceHash(source[2]), Other: "New"},
+	}, result.translate)
+	assert.Equal(t, []*i18n.Message{{ID: "removed", Other: "Supprimé"}}, result.removed)
+}
+
+func TestMergeCommand(t *testing.T) {
+	dir := t.TempDir()
+	source := []*i18n.Message{
+		{ID: "goodbye", Other: "Goodbye"},
+		{ID: "hello", Other: "Hello"},
+	}
+	if err := writeCatalog(filepath.Join(dir, "active.en.json"), source); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if err := writeCatalog(filepath.Join(dir, "active.fr.json"), []*i18n.Message{
+		{ID: "hello", Other: "Bonjour"},
+		{ID: "removed", Other: "Supprimé"},
+	}); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	stdout := new(bytes.Buffer)
+	if err := mergeCommand([]string{"-locales", "de", "-archive", dir}, stdout); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "de: 0 translated, 2 to translate, 0 removed\nfr: 1 translated, 1 to translate, 1 removed\n", stdout.String())
+	active, err := readCatalog(filepath.Join(dir, "active.fr.json"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, []*i18n.Message{{ID: "hello", Hash: sourceHash(source[1]), Other: "Bonjour"}}, active.Messages)
+	archive, err := readCatalog(filepath.Join(dir, "archive.fr.json"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, []*i18n.Message{{ID: "removed", Other: "Supprimé"}}, archive.Messages)
+
+	// translate the stub and merge it back
+	translatePath := filepath.Join(dir, "translate.fr.json")
+	if err := writeCatalog(translatePath, []*i18n.Message{{ID: "goodbye", Hash: sourceHash(source[0]), Other: "Au revoir"}}); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	stdout.Reset()
+	if err := mergeCommand([]string{dir}, stdout); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Contains(t, stdout.String(), "fr: 2 translated, 0 to translate, 0 removed\n")
+	_, err = os.Stat(translatePath)
+	assert.True(t, os.IsNotExist(err))
+
+	// the output is deterministic
+	first, err := os.ReadFile(filepath.Join(dir, "active.fr.json"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if err := mergeCommand([]string{dir}, stdout); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	second, err := os.ReadFile(filepath.Join(dir, "active.fr.json"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, string(first), string(second))
+}
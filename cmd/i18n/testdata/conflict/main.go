@@ -0,0 +1,8 @@
+package main
+
+import "github.com/gopi-frame/i18n"
+
+func init() {
+	i18n.SetDefaultMessage("hello", "Hello")
+	i18n.SetDefaultMessage("hello", "Hi")
+}
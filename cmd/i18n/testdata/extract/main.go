@@ -0,0 +1,40 @@
+package main
+
+import (
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func init() {
+	i18n.SetDefaultMessage("welcome", "Welcome, {{.name}}!")
+	i18n.SetDefaultMessages(map[string]string{
+		"goodbye": "Goodbye",
+	})
+}
+
+// table is not a translator, its T calls are not extracted.
+type table struct{}
+
+func (table) T(id string) string {
+	return id
+}
+
+func render(t translator.Translator, i *i18n.I18n, count int) {
+	// i18n: Greeting on the home page.
+	t.T("welcome", map[string]any{"name": "world"})
+	t.P("cart.items", count)
+	t.M(i18n.Message(&goi18n.Message{
+		ID:          "inbox",
+		Description: "Unread emails",
+		One:         "{{.PluralCount}} email",
+		Other:       "{{.PluralCount}} emails",
+	}), count)
+	i.Scope("billing").T("total")
+	t.T(dynamicID())
+	table{}.T("column")
+}
+
+func dynamicID() string {
+	return "dynamic"
+}
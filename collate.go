@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+)
+
+// collator returns a collator of the language resolved by the translator, the collation given by the "co" extension of
+// the languages of the translator is kept, for example, "de-u-co-phonebk" sorts with the German phonebook order.
+func (i *I18n) collator() *collate.Collator {
+	tag := i.matchedTag()
+	for _, want := range i.tags {
+		if co := want.TypeForKey("co"); co != "" {
+			if t, err := tag.SetTypeForKey("co", co); err == nil {
+				tag = t
+			}
+			break
+		}
+	}
+	return collate.New(tag)
+}
+
+// Compare compares the strings with the collation of the language resolved by the translator,
+// it returns -1, 0 or 1 as a is sorted before, the same as or after b.
+// A collator is created on each call, use [I18n.Sort] or [I18n.SortIDs] to sort many strings.
+func (i *I18n) Compare(a, b string) int {
+	return i.collator().CompareString(a, b)
+}
+
+// Sort sorts the strings with the collation of the language resolved by the translator,
+// for example, "ä" is sorted after "z" in Swedish and after "a" in German.
+func (i *I18n) Sort(values []string) {
+	i.collator().SortStrings(values)
+}
+
+// SortIDs sorts the message ids by their translations, for example, to list the options of a select box in order.
+// The order of the ids with the same translation is kept.
+func (i *I18n) SortIDs(ids []string) {
+	translations := make(map[string]string, len(ids))
+	for _, id := range ids {
+		translations[id] = i.T(id)
+	}
+	c := i.collator()
+	sort.SliceStable(ids, func(a, b int) bool {
+		return c.CompareString(translations[ids[a]], translations[ids[b]]) < 0
+	})
+}
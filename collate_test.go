@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Collate(t *testing.T) {
+	t.Run("sort", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			for _, l := range []string{"sv", "de"} {
+				if err := i.AddMessages(l, Message(&i18n.Message{ID: "yes", Other: "ja"})); err != nil {
+					assert.FailNow(t, err.Error())
+				}
+			}
+			values := []string{"z", "ä", "B", "a"}
+			i.Sort(values)
+			assert.Equal(t, []string{"a", "ä", "B", "z"}, values)
+			sv := i.Locale("sv").(*I18n)
+			sv.Sort(values)
+			assert.Equal(t, []string{"a", "B", "z", "ä"}, values)
+			names := []string{"Mufflon", "Müller"}
+			i.Locale("de").(*I18n).Sort(names)
+			assert.Equal(t, []string{"Mufflon", "Müller"}, names)
+			i.Locale("de-u-co-phonebk").(*I18n).Sort(names)
+			assert.Equal(t, []string{"Müller", "Mufflon"}, names)
+			names = []string{"Mufflon", "Müller"}
+			i.Locale("ja", "de-AT-u-co-phonebk").(*I18n).Sort(names)
+			assert.Equal(t, []string{"Müller", "Mufflon"}, names)
+		}
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			if err := i.AddMessages("sv", Message(&i18n.Message{ID: "yes", Other: "ja"})); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, -1, i.Compare("ä", "z"))
+			assert.Equal(t, 1, i.Locale("sv").(*I18n).Compare("ä", "z"))
+			assert.Equal(t, 0, i.Compare("a", "a"))
+		}
+	})
+
+	t.Run("sort ids", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "color.red", Other: "Red"}),
+				Message(&i18n.Message{ID: "color.green", Other: "Green"}),
+				Message(&i18n.Message{ID: "color.blue", Other: "Blue"}),
+				Message(&i18n.Message{ID: "color.yellow", Other: "Yellow"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("de",
+				Message(&i18n.Message{ID: "color.red", Other: "Rot"}),
+				Message(&i18n.Message{ID: "color.green", Other: "Grün"}),
+				Message(&i18n.Message{ID: "color.blue", Other: "Blau"}),
+				Message(&i18n.Message{ID: "color.yellow", Other: "Gelb"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			ids := []string{"yellow", "red", "green", "blue"}
+			i.Scope("color").(*I18n).SortIDs(ids)
+			assert.Equal(t, []string{"blue", "green", "red", "yellow"}, ids)
+			ids = []string{"color.yellow", "color.red", "color.green", "color.blue"}
+			i.Locale("de").(*I18n).SortIDs(ids)
+			assert.Equal(t, []string{"color.blue", "color.yellow", "color.green", "color.red"}, ids)
+		}
+	})
+}
@@ -0,0 +1,899 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// fluentMaxDepth is the max depth of the message and term references resolved while compiling a Fluent resource.
+const fluentMaxDepth = 10
+
+var templateIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FluentParser returns a parser of Project Fluent (.ftl) resources.
+//
+// Messages and their attributes are compiled to templates, the attribute "attr" of the message "id" is registered
+// as the message "id.attr" and the comment before a message is used as the description:
+//
+//   - { $name } is converted to {{.name}}, NUMBER($n) and DATETIME($d) are converted to the variable itself.
+//   - { -term } and { message } references are resolved within the resource, term arguments and selectors on term
+//     attributes are resolved while compiling.
+//   - A select expression on a variable whose keys are CLDR plural categories is compiled to the plural forms of the
+//     message, it must be the only one of the message and must not be nested, numeric keys like [0] match the exact
+//     value of the variable, so the variable is passed as template data as well as the plural count.
+//   - Other select expressions on variables are compiled to comparisons of the string value of the variable.
+func FluentParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		messages, err := parseFluent(data)
+		if err != nil {
+			return nil, err
+		}
+		var pack []translator.Message
+		for _, m := range messages {
+			pack = append(pack, Message(m))
+		}
+		return MessagePack(pack, tag), nil
+	})
+}
+
+// UnmarshalFluent is an unmarshal function of Project Fluent resources, which can be registered by
+// [I18n.RegisterUnmarshalFunc] to load .ftl files with [I18n.LoadMessageFile], see [FluentParser].
+func UnmarshalFluent(data []byte, v any) error {
+	messages, err := parseFluent(data)
+	if err != nil {
+		return err
+	}
+	raw := make(map[string]any, len(messages))
+	for _, m := range messages {
+		value := map[string]any{}
+		for _, field := range [][2]string{
+			{"description", m.Description},
+			{"zero", m.Zero},
+			{"one", m.One},
+			{"two", m.Two},
+			{"few", m.Few},
+			{"many", m.Many},
+			{"other", m.Other},
+		} {
+			if field[1] != "" {
+				value[field[0]] = field[1]
+			}
+		}
+		raw[m.ID] = value
+	}
+	p, ok := v.(*any)
+	if !ok {
+		return exception.New(fmt.Sprintf("unsupported unmarshal target %T", v))
+	}
+	*p = raw
+	return nil
+}
+
+type fluentEntry struct {
+	id          string
+	term        bool
+	value       fluentPattern
+	attributes  map[string]fluentPattern
+	attrOrder   []string
+	description string
+}
+
+type fluentPattern []fluentElement
+
+type fluentElement struct {
+	text      string
+	expr      *fluentExpr
+	selection *fluentSelect
+}
+
+type fluentExpr struct {
+	kind  string // variable, term, message, string, number or function
+	name  string
+	attr  string
+	args  []fluentExpr
+	named map[string]fluentExpr
+}
+
+type fluentSelect struct {
+	selector fluentExpr
+	variants []fluentVariant
+	fallback int
+}
+
+type fluentVariant struct {
+	key   string
+	value fluentPattern
+}
+
+func parseFluent(data []byte) ([]*i18n.Message, error) {
+	p := &fluentParser{src: strings.ReplaceAll(string(data), "\r\n", "\n")}
+	entries, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	c := &fluentCompiler{entries: make(map[string]*fluentEntry)}
+	for _, entry := range entries {
+		key := entry.id
+		if entry.term {
+			key = "-" + key
+		}
+		c.entries[key] = entry
+	}
+	var messages []*i18n.Message
+	for _, entry := range entries {
+		if entry.term {
+			continue
+		}
+		if entry.value != nil {
+			m, err := c.compileMessage(entry.id, entry.value)
+			if err != nil {
+				return nil, err
+			}
+			m.Description = entry.description
+			messages = append(messages, m)
+		}
+		for _, attr := range entry.attrOrder {
+			m, err := c.compileMessage(entry.id+"."+attr, entry.attributes[attr])
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+type fluentParser struct {
+	src string
+	pos int
+}
+
+func (p *fluentParser) errorf(format string, args ...any) error {
+	line := strings.Count(p.src[:min(p.pos, len(p.src))], "\n") + 1
+	return exception.New(fmt.Sprintf("fluent: line %d: %s", line, fmt.Sprintf(format, args...)))
+}
+
+func (p *fluentParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *fluentParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *fluentParser) skipInlineSpace() {
+	for !p.eof() && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *fluentParser) skipBlank() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *fluentParser) readLine() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		line := p.src[p.pos:]
+		p.pos = len(p.src)
+		return line
+	}
+	line := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return line
+}
+
+func (p *fluentParser) parse() ([]*fluentEntry, error) {
+	var entries []*fluentEntry
+	var comments []string
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == '\n':
+			p.pos++
+			comments = nil
+		case c == '#':
+			line := p.readLine()
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			if level == 1 {
+				comments = append(comments, strings.TrimSpace(line[1:]))
+			} else {
+				comments = nil
+			}
+		case c == '-' || isFluentIdentifierStart(c):
+			entry, err := p.parseEntry()
+			if err != nil {
+				return nil, err
+			}
+			entry.description = strings.Join(comments, "\n")
+			comments = nil
+			entries = append(entries, entry)
+		default:
+			return nil, p.errorf("expected an entry")
+		}
+	}
+	return entries, nil
+}
+
+func isFluentIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isFluentIdentifierChar(c byte) bool {
+	return isFluentIdentifierStart(c) || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *fluentParser) parseIdentifier() (string, error) {
+	start := p.pos
+	if p.eof() || !isFluentIdentifierStart(p.src[p.pos]) {
+		return "", p.errorf("expected an identifier")
+	}
+	for !p.eof() && isFluentIdentifierChar(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos], nil
+}
+
+func (p *fluentParser) parseEntry() (*fluentEntry, error) {
+	entry := &fluentEntry{attributes: make(map[string]fluentPattern)}
+	if p.peek() == '-' {
+		entry.term = true
+		p.pos++
+	}
+	id, err := p.parseIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	entry.id = id
+	p.skipInlineSpace()
+	if p.peek() != '=' {
+		return nil, p.errorf("expected \"=\" after %q", id)
+	}
+	p.pos++
+	value, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+	if len(value) > 0 {
+		entry.value = value
+	}
+	for {
+		start := p.pos
+		p.skipBlank()
+		if p.pos == start || p.peek() != '.' || p.pos > 0 && p.src[p.pos-1] == '\n' {
+			p.pos = start
+			break
+		}
+		p.pos++
+		attr, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		p.skipInlineSpace()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected \"=\" after attribute %q", attr)
+		}
+		p.pos++
+		value, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+		entry.attributes[attr] = value
+		entry.attrOrder = append(entry.attrOrder, attr)
+	}
+	if entry.value == nil && len(entry.attributes) == 0 {
+		return nil, p.errorf("expected a value or an attribute of %q", id)
+	}
+	if entry.term && entry.value == nil {
+		return nil, p.errorf("expected a value of term %q", id)
+	}
+	return entry, nil
+}
+
+// parsePattern parses a pattern until the end of the last line belonging to it.
+func (p *fluentParser) parsePattern() (fluentPattern, error) {
+	var pattern fluentPattern
+	var text strings.Builder
+	p.skipInlineSpace()
+	flush := func() {
+		if text.Len() > 0 {
+			pattern = append(pattern, fluentElement{text: text.String()})
+			text.Reset()
+		}
+	}
+	leading := true
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch c {
+		case '{':
+			p.pos++
+			flush()
+			element, err := p.parsePlaceable()
+			if err != nil {
+				return nil, err
+			}
+			pattern = append(pattern, element)
+			leading = false
+		case '}':
+			return nil, p.errorf("unbalanced closing brace")
+		case '\n':
+			if !p.continues() {
+				flush()
+				return trimPattern(pattern), nil
+			}
+			if !leading {
+				text.WriteByte('\n')
+			}
+			p.pos++
+			p.skipBlank()
+		default:
+			text.WriteByte(c)
+			p.pos++
+			leading = false
+		}
+	}
+	flush()
+	return trimPattern(pattern), nil
+}
+
+// continues reports whether the line after the current newline continues the pattern.
+func (p *fluentParser) continues() bool {
+	i := p.pos
+	for i < len(p.src) && (p.src[i] == '\n' || p.src[i] == ' ') {
+		i++
+	}
+	if i >= len(p.src) {
+		return false
+	}
+	lineStart := strings.LastIndexByte(p.src[:i], '\n') + 1
+	if lineStart == i {
+		return false
+	}
+	switch p.src[i] {
+	case '.', '[', '*', '}':
+		return false
+	}
+	return true
+}
+
+func trimPattern(pattern fluentPattern) fluentPattern {
+	if len(pattern) > 0 && pattern[len(pattern)-1].expr == nil && pattern[len(pattern)-1].selection == nil {
+		last := strings.TrimRight(pattern[len(pattern)-1].text, " \n")
+		if last == "" {
+			pattern = pattern[:len(pattern)-1]
+		} else {
+			pattern[len(pattern)-1].text = last
+		}
+	}
+	return pattern
+}
+
+func (p *fluentParser) parsePlaceable() (fluentElement, error) {
+	p.skipBlank()
+	if p.peek() == '{' {
+		p.pos++
+		inner, err := p.parsePlaceable()
+		if err != nil {
+			return fluentElement{}, err
+		}
+		p.skipBlank()
+		if p.peek() != '}' {
+			return fluentElement{}, p.errorf("expected \"}\"")
+		}
+		p.pos++
+		return inner, nil
+	}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return fluentElement{}, err
+	}
+	p.skipBlank()
+	if strings.HasPrefix(p.src[p.pos:], "->") {
+		p.pos += 2
+		selection, err := p.parseVariants(expr)
+		if err != nil {
+			return fluentElement{}, err
+		}
+		return fluentElement{selection: selection}, nil
+	}
+	if p.peek() != '}' {
+		return fluentElement{}, p.errorf("expected \"}\"")
+	}
+	p.pos++
+	return fluentElement{expr: &expr}, nil
+}
+
+func (p *fluentParser) parseVariants(selector fluentExpr) (*fluentSelect, error) {
+	selection := &fluentSelect{selector: selector, fallback: -1}
+	for {
+		p.skipBlank()
+		switch {
+		case p.peek() == '}':
+			p.pos++
+			if selection.fallback < 0 {
+				return nil, p.errorf("missing default variant")
+			}
+			return selection, nil
+		case p.peek() == '*' || p.peek() == '[':
+			if p.peek() == '*' {
+				if selection.fallback >= 0 {
+					return nil, p.errorf("multiple default variants")
+				}
+				selection.fallback = len(selection.variants)
+				p.pos++
+			}
+			if p.peek() != '[' {
+				return nil, p.errorf("expected \"[\"")
+			}
+			end := strings.IndexByte(p.src[p.pos:], ']')
+			if end < 0 {
+				return nil, p.errorf("expected \"]\"")
+			}
+			key := strings.TrimSpace(p.src[p.pos+1 : p.pos+end])
+			p.pos += end + 1
+			value, err := p.parseVariantPattern()
+			if err != nil {
+				return nil, err
+			}
+			selection.variants = append(selection.variants, fluentVariant{key: key, value: value})
+		default:
+			return nil, p.errorf("expected a variant")
+		}
+	}
+}
+
+// parseVariantPattern parses the pattern of a variant, which ends before the next variant or the closing brace.
+func (p *fluentParser) parseVariantPattern() (fluentPattern, error) {
+	var pattern fluentPattern
+	var text strings.Builder
+	p.skipInlineSpace()
+	flush := func() {
+		if text.Len() > 0 {
+			pattern = append(pattern, fluentElement{text: text.String()})
+			text.Reset()
+		}
+	}
+	leading := true
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch c {
+		case '{':
+			p.pos++
+			flush()
+			element, err := p.parsePlaceable()
+			if err != nil {
+				return nil, err
+			}
+			pattern = append(pattern, element)
+			leading = false
+		case '}':
+			flush()
+			return trimPattern(pattern), nil
+		case '\n':
+			i := p.pos
+			for i < len(p.src) && (p.src[i] == '\n' || p.src[i] == ' ') {
+				i++
+			}
+			if i >= len(p.src) || p.src[i] == '[' || p.src[i] == '*' || p.src[i] == '}' {
+				flush()
+				p.pos = i
+				return trimPattern(pattern), nil
+			}
+			if !leading {
+				text.WriteByte('\n')
+			}
+			p.pos = i
+		default:
+			text.WriteByte(c)
+			p.pos++
+			leading = false
+		}
+	}
+	return nil, p.errorf("unterminated select expression")
+}
+
+func (p *fluentParser) parseExpression() (fluentExpr, error) {
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		name, err := p.parseIdentifier()
+		return fluentExpr{kind: "variable", name: name}, err
+	case c == '"':
+		p.pos++
+		var sb strings.Builder
+		for !p.eof() && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' && p.pos+1 < len(p.src) {
+				p.pos++
+				switch p.src[p.pos] {
+				case 'u':
+					if p.pos+5 > len(p.src) {
+						return fluentExpr{}, p.errorf("invalid unicode escape")
+					}
+					code, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32)
+					if err != nil {
+						return fluentExpr{}, p.errorf("invalid unicode escape")
+					}
+					sb.WriteRune(rune(code))
+					p.pos += 4
+				default:
+					sb.WriteByte(p.src[p.pos])
+				}
+			} else {
+				sb.WriteByte(p.src[p.pos])
+			}
+			p.pos++
+		}
+		if p.eof() {
+			return fluentExpr{}, p.errorf("unterminated string literal")
+		}
+		p.pos++
+		return fluentExpr{kind: "string", name: sb.String()}, nil
+	case c == '-' && p.pos+1 < len(p.src) && (p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9'), c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for !p.eof() && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		return fluentExpr{kind: "number", name: p.src[start:p.pos]}, nil
+	case c == '-':
+		p.pos++
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return fluentExpr{}, err
+		}
+		expr := fluentExpr{kind: "term", name: name}
+		if p.peek() == '.' {
+			p.pos++
+			if expr.attr, err = p.parseIdentifier(); err != nil {
+				return fluentExpr{}, err
+			}
+		}
+		if p.peek() == '(' {
+			if err := p.parseArguments(&expr); err != nil {
+				return fluentExpr{}, err
+			}
+		}
+		return expr, nil
+	case isFluentIdentifierStart(c):
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return fluentExpr{}, err
+		}
+		if p.peek() == '(' {
+			expr := fluentExpr{kind: "function", name: name}
+			if err := p.parseArguments(&expr); err != nil {
+				return fluentExpr{}, err
+			}
+			return expr, nil
+		}
+		expr := fluentExpr{kind: "message", name: name}
+		if p.peek() == '.' {
+			p.pos++
+			if expr.attr, err = p.parseIdentifier(); err != nil {
+				return fluentExpr{}, err
+			}
+		}
+		return expr, nil
+	default:
+		return fluentExpr{}, p.errorf("expected an expression")
+	}
+}
+
+func (p *fluentParser) parseArguments(expr *fluentExpr) error {
+	p.pos++
+	expr.named = make(map[string]fluentExpr)
+	for {
+		p.skipBlank()
+		if p.peek() == ')' {
+			p.pos++
+			return nil
+		}
+		arg, err := p.parseExpression()
+		if err != nil {
+			return err
+		}
+		p.skipBlank()
+		if p.peek() == ':' && arg.kind == "message" {
+			p.pos++
+			p.skipBlank()
+			value, err := p.parseExpression()
+			if err != nil {
+				return err
+			}
+			expr.named[arg.name] = value
+		} else {
+			expr.args = append(expr.args, arg)
+		}
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ')' {
+			return p.errorf("expected \",\" or \")\"")
+		}
+	}
+}
+
+type fluentCompiler struct {
+	entries map[string]*fluentEntry
+}
+
+// compileMessage compiles the pattern to a message, the top level plural selection is compiled to the plural forms.
+func (c *fluentCompiler) compileMessage(id string, pattern fluentPattern) (*i18n.Message, error) {
+	m := &i18n.Message{ID: id}
+	index := -1
+	for i, element := range pattern {
+		if element.selection != nil && c.isPluralSelection(element.selection, nil) {
+			if index >= 0 {
+				return nil, exception.New(fmt.Sprintf("fluent: message %q has more than one plural selection", id))
+			}
+			index = i
+		}
+	}
+	if index < 0 {
+		other, err := c.compile(pattern, nil, 0)
+		if err != nil {
+			return nil, exception.New(fmt.Sprintf("fluent: message %q: %s", id, err.Error()))
+		}
+		m.Other = other
+		return m, nil
+	}
+	prefix, err := c.compile(pattern[:index], nil, 0)
+	if err != nil {
+		return nil, exception.New(fmt.Sprintf("fluent: message %q: %s", id, err.Error()))
+	}
+	suffix, err := c.compile(pattern[index+1:], nil, 0)
+	if err != nil {
+		return nil, exception.New(fmt.Sprintf("fluent: message %q: %s", id, err.Error()))
+	}
+	selection := pattern[index].selection
+	variable := c.variable(selection.selector)
+	var exact []fluentVariant
+	categories := make(map[string]fluentPattern)
+	for i, variant := range selection.variants {
+		if _, err := strconv.ParseFloat(variant.key, 64); err == nil {
+			exact = append(exact, variant)
+			continue
+		}
+		categories[variant.key] = variant.value
+		if i == selection.fallback {
+			categories["other"] = variant.value
+		}
+	}
+	if _, ok := categories["other"]; !ok {
+		categories["other"] = selection.variants[selection.fallback].value
+	}
+	for _, form := range pluralKeys {
+		value, ok := categories[form]
+		if !ok {
+			continue
+		}
+		compiled, err := c.compile(value, nil, 0)
+		if err != nil {
+			return nil, exception.New(fmt.Sprintf("fluent: message %q: %s", id, err.Error()))
+		}
+		for i := len(exact) - 1; i >= 0; i-- {
+			exactValue, err := c.compile(exact[i].value, nil, 0)
+			if err != nil {
+				return nil, exception.New(fmt.Sprintf("fluent: message %q: %s", id, err.Error()))
+			}
+			compiled = "{{if eq (print " + variable + ") " + strconv.Quote(exact[i].key) + "}}" + exactValue + "{{else}}" + compiled + "{{end}}"
+		}
+		setMessageField(m, form, prefix+compiled+suffix)
+	}
+	return m, nil
+}
+
+// isPluralSelection reports whether the selection is on a variable with CLDR plural categories or numbers as keys.
+func (c *fluentCompiler) isPluralSelection(selection *fluentSelect, env map[string]fluentExpr) bool {
+	selector := selection.selector
+	if selector.kind == "function" && selector.name == "NUMBER" && len(selector.args) > 0 {
+		selector = selector.args[0]
+	}
+	if selector.kind != "variable" {
+		return false
+	}
+	if _, ok := env[selector.name]; ok {
+		return false
+	}
+	hasCategory := false
+	for _, variant := range selection.variants {
+		if _, err := strconv.ParseFloat(variant.key, 64); err == nil {
+			continue
+		}
+		isCategory := false
+		for _, form := range pluralKeys {
+			if variant.key == form {
+				isCategory = true
+			}
+		}
+		if !isCategory {
+			return false
+		}
+		if variant.key != "other" {
+			hasCategory = true
+		}
+	}
+	return hasCategory
+}
+
+func (c *fluentCompiler) variable(expr fluentExpr) string {
+	if expr.kind == "function" && len(expr.args) > 0 {
+		expr = expr.args[0]
+	}
+	if templateIdentifier.MatchString(expr.name) {
+		return "." + expr.name
+	}
+	return "(index . " + strconv.Quote(expr.name) + ")"
+}
+
+// compile compiles the pattern to a template, env holds the arguments of the term being compiled.
+func (c *fluentCompiler) compile(pattern fluentPattern, env map[string]fluentExpr, depth int) (string, error) {
+	if depth > fluentMaxDepth {
+		return "", exception.New("too deep or cyclic references")
+	}
+	var sb strings.Builder
+	for _, element := range pattern {
+		switch {
+		case element.expr != nil:
+			s, err := c.compileExpr(*element.expr, env, depth)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		case element.selection != nil:
+			s, err := c.compileSelection(element.selection, env, depth)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		default:
+			sb.WriteString(escapeTemplateText(element.text))
+		}
+	}
+	return sb.String(), nil
+}
+
+func (c *fluentCompiler) compileExpr(expr fluentExpr, env map[string]fluentExpr, depth int) (string, error) {
+	switch expr.kind {
+	case "string", "number":
+		return escapeTemplateText(expr.name), nil
+	case "variable":
+		if value, ok := env[expr.name]; ok {
+			return c.compileExpr(value, nil, depth+1)
+		}
+		if env != nil {
+			return "", nil
+		}
+		return "{{" + c.variable(expr) + "}}", nil
+	case "function":
+		if len(expr.args) == 0 {
+			return "", exception.New(fmt.Sprintf("missing argument of function %s", expr.name))
+		}
+		return c.compileExpr(expr.args[0], env, depth)
+	case "term", "message":
+		pattern, err := c.resolve(expr)
+		if err != nil {
+			return "", err
+		}
+		var termEnv map[string]fluentExpr
+		if expr.kind == "term" {
+			// terms only see their own arguments.
+			termEnv = make(map[string]fluentExpr, len(expr.named))
+			for name, value := range expr.named {
+				termEnv[name] = value
+			}
+		}
+		return c.compile(pattern, termEnv, depth+1)
+	}
+	return "", exception.New(fmt.Sprintf("unsupported expression %q", expr.kind))
+}
+
+func (c *fluentCompiler) resolve(expr fluentExpr) (fluentPattern, error) {
+	key := expr.name
+	if expr.kind == "term" {
+		key = "-" + key
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, exception.New(fmt.Sprintf("unknown reference %q", key))
+	}
+	if expr.attr == "" {
+		return entry.value, nil
+	}
+	pattern, ok := entry.attributes[expr.attr]
+	if !ok {
+		return nil, exception.New(fmt.Sprintf("unknown attribute %q of %q", expr.attr, key))
+	}
+	return pattern, nil
+}
+
+func (c *fluentCompiler) compileSelection(selection *fluentSelect, env map[string]fluentExpr, depth int) (string, error) {
+	selector := selection.selector
+	if selector.kind == "function" && len(selector.args) > 0 {
+		selector = selector.args[0]
+	}
+	if value, ok := env[selector.name]; selector.kind == "variable" && ok {
+		selector = value
+	}
+	switch selector.kind {
+	case "string", "number", "term", "message":
+		// the selector is known while compiling, select the variant statically.
+		key := selector.name
+		if selector.kind == "term" || selector.kind == "message" {
+			pattern, err := c.resolve(selector)
+			if err != nil {
+				return "", err
+			}
+			if key, err = c.compile(pattern, selector.named, depth+1); err != nil {
+				return "", err
+			}
+		}
+		for _, variant := range selection.variants {
+			if variant.key == key {
+				return c.compile(variant.value, env, depth+1)
+			}
+		}
+		return c.compile(selection.variants[selection.fallback].value, env, depth+1)
+	case "variable":
+		if env != nil {
+			// the variable is not an argument of the term being compiled.
+			return c.compile(selection.variants[selection.fallback].value, env, depth+1)
+		}
+		if c.isPluralSelection(selection, env) {
+			return "", exception.New("plural selections must be at the top level of a message")
+		}
+		variable := c.variable(selector)
+		var sb strings.Builder
+		keys := make([]int, 0, len(selection.variants))
+		for i := range selection.variants {
+			if i != selection.fallback {
+				keys = append(keys, i)
+			}
+		}
+		for n, i := range keys {
+			value, err := c.compile(selection.variants[i].value, env, depth+1)
+			if err != nil {
+				return "", err
+			}
+			if n == 0 {
+				sb.WriteString("{{if ")
+			} else {
+				sb.WriteString("{{else if ")
+			}
+			sb.WriteString("eq (print " + variable + ") " + strconv.Quote(selection.variants[i].key) + "}}" + value)
+		}
+		fallback, err := c.compile(selection.variants[selection.fallback].value, env, depth+1)
+		if err != nil {
+			return "", err
+		}
+		if len(keys) == 0 {
+			return fallback, nil
+		}
+		sb.WriteString("{{else}}" + fallback + "{{end}}")
+		return sb.String(), nil
+	}
+	return "", exception.New(fmt.Sprintf("unsupported selector %q", selector.kind))
+}
+
+// escapeTemplateText escapes the template delimiters in the literal text, a trailing "{" is escaped as well since
+// it would be merged with the following action.
+func escapeTemplateText(text string) string {
+	if strings.Contains(text, "{{") || strings.Contains(text, "}}") {
+		text = strings.NewReplacer("{{", `{{"{{"}}`, "}}", `{{"}}"}}`).Replace(text)
+	}
+	if strings.HasSuffix(text, "{") {
+		text = text[:len(text)-1] + `{{"{"}}`
+	}
+	return text
+}
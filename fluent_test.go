@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+const testFluent = `
+-brand = Gopi
+    .gender = neuter
+-app = { $case ->
+    [genitive] { -brand }'s app
+   *[nominative] { -brand } app
+}
+
+# The welcome message.
+welcome = Welcome to { -brand }, { $name }!
+about = About { -app(case: "genitive") }
+pronoun = { -brand.gender ->
+    [masculine] he
+    [feminine] she
+   *[other] it
+}
+emails = { $count ->
+    [0] No new emails
+    [one] { $count } new email
+   *[other] { $count } new emails
+} in { $folder }
+shared = { $gender ->
+    [male] He shared
+    [female] She shared
+   *[other] They shared
+} a photo.
+login =
+    .placeholder = Email
+    .title = Log in to { -brand }
+multiline =
+    First line
+    second line
+braces = {"{"}{ $name }{"}"}
+`
+
+func TestFluentParser(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+			return []byte(testFluent), nil
+		}), FluentParser(language.English))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		t.Run("variable and term reference", func(t *testing.T) {
+			assert.Equal(t, "Welcome to Gopi, world!", i.T("welcome", map[string]any{"name": "world"}))
+		})
+		t.Run("term arguments", func(t *testing.T) {
+			assert.Equal(t, "About Gopi's app", i.T("about"))
+		})
+		t.Run("term attribute selector", func(t *testing.T) {
+			assert.Equal(t, "it", i.T("pronoun"))
+		})
+		t.Run("plural selector", func(t *testing.T) {
+			assert.Equal(t, "No new emails in inbox", i.P("emails", 0, map[string]any{"count": 0, "folder": "inbox"}))
+			assert.Equal(t, "1 new email in inbox", i.P("emails", 1, map[string]any{"count": 1, "folder": "inbox"}))
+			assert.Equal(t, "5 new emails in inbox", i.P("emails", 5, map[string]any{"count": 5, "folder": "inbox"}))
+		})
+		t.Run("string selector", func(t *testing.T) {
+			assert.Equal(t, "She shared a photo.", i.T("shared", map[string]any{"gender": "female"}))
+			assert.Equal(t, "They shared a photo.", i.T("shared", map[string]any{"gender": "unknown"}))
+			assert.Equal(t, "They shared a photo.", i.T("shared"))
+		})
+		t.Run("attributes", func(t *testing.T) {
+			assert.Equal(t, "Email", i.T("login.placeholder"))
+			assert.Equal(t, "Log in to Gopi", i.T("login.title"))
+		})
+		t.Run("multiline", func(t *testing.T) {
+			assert.Equal(t, "First line\nsecond line", i.T("multiline"))
+		})
+		t.Run("string literals", func(t *testing.T) {
+			assert.Equal(t, "{world}", i.T("braces", map[string]any{"name": "world"}))
+		})
+	}
+}
+
+func TestFluentParser_Description(t *testing.T) {
+	messagePack, err := FluentParser(language.English).Parse([]byte(testFluent))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	for _, message := range messagePack.GetMessages() {
+		if message.GetID() == "welcome" {
+			assert.Equal(t, "The welcome message.", message.GetDescription())
+			return
+		}
+	}
+	assert.Fail(t, "missing message welcome")
+}
+
+func TestFluentParser_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown term":          "hello = { -brand }",
+		"missing default":       "hello = { $x ->\n    [a] A\n}",
+		"nested plural":         "hello = { $gender ->\n    [male] { $count ->\n        [one] one\n       *[other] other\n    }\n   *[other] x\n}",
+		"cyclic reference":      "a = { b }\nb = { a }",
+		"unterminated":          "hello = { $name",
+		"multiple plural forms": "hello = { $a ->\n    [one] one\n   *[other] other\n} { $b ->\n    [one] one\n   *[other] other\n}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := FluentParser(language.English).Parse([]byte(content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnmarshalFluent(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		i.RegisterUnmarshalFunc("ftl", UnmarshalFluent)
+		if err := i.LoadMessageFile("testdata/fluent.en.ftl"); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "Welcome to Gopi, world!", i.T("welcome", map[string]any{"name": "world"}))
+		assert.Equal(t, "1 new email", i.P("emails", 1, map[string]any{"count": 1}))
+		assert.Equal(t, "2 new emails", i.P("emails", 2, map[string]any{"count": 2}))
+	}
+}
@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// maxReferenceDepth is the max depth of the message references resolved by the t template function.
+const maxReferenceDepth = 10
+
+// templateFuncs returns the functions of the templates of the message, bound to the language resolved by the translator.
+func (i *I18n) templateFuncs(id string) template.FuncMap {
+	return template.FuncMap{
+		"t": func(ref string, data ...any) (any, error) {
+			s, err := i.reference(id, ref, data)
+			if i.escapeHTML {
+				// the referenced message is rendered as HTML too.
+				return htmltemplate.HTML(s), err
+			}
+			return s, err
+		},
+		"list":     i.listFunc,
+		"unit":     i.unitFunc,
+		"bytes":    i.bytesFunc,
+		"duration": i.durationFunc,
+	}
+}
+
+// referenceError is the error of a circular or too deep message reference, it fails the referencing messages too.
+type referenceError struct {
+	message string
+}
+
+func (e *referenceError) Error() string {
+	return e.message
+}
+
+// reference localizes the message referenced by the message from with the t template function, for example,
+// {{t "brand.name"}}. The id is not prefixed by the scope of the translator, and the data is given the same way as to
+// [I18n.T]. The message of the default language is used if the resolved language has no such message.
+func (i *I18n) reference(from, id string, data []any) (string, error) {
+	refs := append(append([]string{}, i.refs...), from)
+	for index, ref := range refs {
+		if ref == id {
+			return "", &referenceError{fmt.Sprintf("circular message reference %s -> %s", strings.Join(refs[index:], " -> "), id)}
+		}
+	}
+	if len(refs) > maxReferenceDepth {
+		return "", &referenceError{fmt.Sprintf("message reference %s -> %s exceeds the max depth %d", strings.Join(refs, " -> "), id, maxReferenceDepth)}
+	}
+	r := *i
+	r.refs = refs
+	s, err := r.localize(&i18n.LocalizeConfig{
+		MessageID:    id,
+		TemplateData: templateData(data),
+	})
+	if err != nil {
+		var notFound *i18n.MessageNotFoundErr
+		if errors.As(err, &notFound) && s != "" {
+			return s, nil
+		}
+		var refErr *referenceError
+		if errors.As(err, &refErr) {
+			return "", err
+		}
+		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+			return defaultMessage, nil
+		}
+		return id, nil
+	}
+	return s, nil
+}
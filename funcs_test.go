@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Reference(t *testing.T) {
+	t.Run("reference", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "brand.name", Other: "Acme"}),
+				Message(&i18n.Message{ID: "brand.slogan", Other: "{{t \"brand.name\"}} makes it easy"}),
+				Message(&i18n.Message{ID: "welcome", Other: "Welcome to {{t \"brand.name\"}}, {{.name}}!"}),
+				Message(&i18n.Message{ID: "greeting", Other: "Hello, {{.name}}"}),
+				Message(&i18n.Message{ID: "signature", Other: "{{t \"greeting\" \"name\" .name}}. {{t \"brand.slogan\"}}."}),
+				Message(&i18n.Message{ID: "broken", Other: "See {{t \"missing\"}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("fr", Message(&i18n.Message{ID: "welcome", Other: "Bienvenue chez {{t \"brand.name\"}}, {{.name}} !"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Welcome to Acme, Alice!", i.T("welcome", "name", "Alice"))
+			assert.Equal(t, "Hello, Bob. Acme makes it easy.", i.T("signature", "name", "Bob"))
+			assert.Equal(t, "Bienvenue chez Acme, Alice !", i.Locale("fr").T("welcome", "name", "Alice"))
+			assert.Equal(t, "Acme makes it easy", i.Scope("brand").T("slogan"))
+			assert.Equal(t, "See missing", i.T("broken"))
+		}
+	})
+
+	t.Run("circular reference", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "a", Other: "a {{t \"b\"}}"}),
+				Message(&i18n.Message{ID: "b", Other: "b {{t \"a\"}}"}),
+				Message(&i18n.Message{ID: "self", Other: "{{t \"self\"}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "a", i.T("a"))
+			assert.Equal(t, "self", i.T("self"))
+			_, err = i.localize(&i18n.LocalizeConfig{MessageID: "a"})
+			assert.ErrorContains(t, err, "circular message reference a -> b -> a")
+		}
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			for n := 0; n < maxReferenceDepth+1; n++ {
+				err = i.AddMessages("en", Message(&i18n.Message{ID: fmt.Sprintf("m%d", n), Other: fmt.Sprintf("{{t \"m%d\"}}", n+1)}))
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+			}
+			err = i.AddMessages("en", Message(&i18n.Message{ID: fmt.Sprintf("m%d", maxReferenceDepth+1), Other: "end"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "end", i.T("m1"))
+			assert.Equal(t, "m0", i.T("m0"))
+		}
+	})
+}
@@ -1,13 +1,16 @@
 module github.com/gopi-frame/i18n
 
-go 1.22.2
+go 1.23.0
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/gopi-frame/contract/translator v0.0.0-20241028033443-ba86f7aad126
 	github.com/gopi-frame/exception v0.0.0-20240903061238-ba7913087614
 	github.com/nicksnyder/go-i18n/v2 v2.4.1
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/text v0.20.0
+	golang.org/x/tools v0.34.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -15,5 +18,6 @@ require (
 	github.com/gopi-frame/contract v0.0.0-20240628085022-04f690d0496f // indirect
 	github.com/gopi-frame/contract/exception v0.0.0-20241028033443-ba86f7aad126 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
 )
@@ -0,0 +1,274 @@
+package i18n
+
+import (
+	"bytes"
+	"html"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+)
+
+// DefaultHTMLTags are the tags allowed in the translations rendered by [I18n.HTML] by default,
+// with their allowed attributes.
+var DefaultHTMLTags = map[string][]string{
+	"a":      {"href", "title", "target", "rel"},
+	"abbr":   {"title"},
+	"b":      nil,
+	"bdi":    {"dir"},
+	"br":     nil,
+	"code":   nil,
+	"em":     nil,
+	"i":      nil,
+	"kbd":    nil,
+	"mark":   nil,
+	"s":      nil,
+	"small":  nil,
+	"span":   {"class"},
+	"strong": nil,
+	"sub":    nil,
+	"sup":    nil,
+	"u":      nil,
+}
+
+var (
+	htmlTag       = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9-]*)((?:[\s/][^>]*)?)>$`)
+	htmlAttribute = regexp.MustCompile(`([^\s"'>/=]+)(?:\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+))?`)
+	urlAttributes = map[string]bool{"href": true, "src": true, "action": true, "formaction": true}
+	safeSchemes   = map[string]bool{"http": true, "https": true, "mailto": true, "tel": true}
+)
+
+// SetHTMLTags sets the tags allowed in the translations rendered by [I18n.HTML] with their allowed attributes,
+// it defaults to [DefaultHTMLTags].
+func (i *I18n) SetHTMLTags(tags map[string][]string) {
+	i.htmlTags = tags
+}
+
+// HTML returns the translation for the given id as HTML.
+//
+// The message is rendered by [html/template], the template data is escaped contextually, for example, as text, as an
+// attribute or as a URL, and the markup of the translation itself is kept if its tags and attributes are allowed,
+// see [I18n.SetHTMLTags]. The other tags are escaped, as well as the tags with literal URLs of other schemes than http,
+// https, mailto and tel. Message references with the t template function are rendered as HTML too.
+// If the length of data is even, it will be used as key-value pairs.
+// If the length of data is greater than 1 and is odd, it will be used as a slice.
+//
+//	// "Hello, <b>{{.name}}</b>!" is rendered as "Hello, <b>&lt;script&gt;</b>!"
+//	i.HTML("greeting", "name", "<script>")
+func (i *I18n) HTML(id string, data ...any) htmltemplate.HTML {
+	return i.localizeHTML(id, nil, data)
+}
+
+// HTMLP returns the translation for the given id and plural count as HTML, see [I18n.HTML] and [I18n.P].
+func (i *I18n) HTMLP(id string, pluralCount any, data ...any) htmltemplate.HTML {
+	return i.localizeHTML(id, pluralCount, data)
+}
+
+// FuncMap returns the t and tp functions calling [I18n.HTML] and [I18n.HTMLP] for html/template views.
+// The functions are bound to the translator, use the functions of a translator returned by [I18n.Locale]
+// to render a view in the language of a request.
+//
+//	view.Funcs(i.Locale(lang).(*i18n.I18n).FuncMap()).Execute(w, data)
+//
+//	{{t "greeting" "name" .User.Name}} {{tp "items" .Count}}
+func (i *I18n) FuncMap() htmltemplate.FuncMap {
+	return htmltemplate.FuncMap{
+		"t":  i.HTML,
+		"tp": i.HTMLP,
+	}
+}
+
+func (i *I18n) localizeHTML(id string, pluralCount any, data []any) htmltemplate.HTML {
+	h := *i
+	h.escapeHTML = true
+	id = h.prefix + id
+	lc := &i18n.LocalizeConfig{
+		MessageID:    id,
+		PluralCount:  pluralCount,
+		TemplateData: pluralTemplateData(data, pluralCount),
+	}
+	r, err := h.localize(lc)
+	if err != nil {
+		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+			return htmltemplate.HTML(html.EscapeString(defaultMessage))
+		}
+		return htmltemplate.HTML(html.EscapeString(id))
+	}
+	return htmltemplate.HTML(r)
+}
+
+// htmlParser parses the templates with html/template, the markup of the template is sanitized first.
+type htmlParser struct {
+	funcs texttemplate.FuncMap
+	tags  map[string][]string
+}
+
+func (p *htmlParser) Cacheable() bool {
+	return false
+}
+
+func (p *htmlParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	tags := p.tags
+	if tags == nil {
+		tags = DefaultHTMLTags
+	}
+	tmpl, err := htmltemplate.New("").Delims(leftDelim, rightDelim).Funcs(htmltemplate.FuncMap(p.funcs)).
+		Parse(sanitizeHTML(src, leftDelim, rightDelim, tags))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedHTMLTemplate{tmpl: tmpl}, nil
+}
+
+type parsedHTMLTemplate struct {
+	tmpl *htmltemplate.Template
+}
+
+func (t *parsedHTMLTemplate) Execute(data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sanitizeHTML escapes the tags of the template source which are not allowed, as well as the closing tags which do not
+// close a kept tag, the template actions are kept intact.
+func sanitizeHTML(src, leftDelim, rightDelim string, tags map[string][]string) string {
+	var sb strings.Builder
+	open := map[string]int{}
+	for k := 0; k < len(src); {
+		if strings.HasPrefix(src[k:], leftDelim) {
+			end := actionEnd(src, k, leftDelim, rightDelim)
+			sb.WriteString(src[k:end])
+			k = end
+			continue
+		}
+		if src[k] == '<' {
+			if end, ok := tagEnd(src, k, leftDelim, rightDelim); ok {
+				if name, closing, allowed := allowedTag(src[k:end], leftDelim, rightDelim, tags); allowed && (!closing || open[name] > 0) {
+					if closing {
+						open[name]--
+					} else {
+						open[name]++
+					}
+					sb.WriteString(src[k:end])
+					k = end
+					continue
+				}
+			}
+			sb.WriteString("&lt;")
+			k++
+			continue
+		}
+		sb.WriteByte(src[k])
+		k++
+	}
+	return sb.String()
+}
+
+// actionEnd returns the index after the template action starting at start.
+func actionEnd(src string, start int, leftDelim, rightDelim string) int {
+	end := strings.Index(src[start+len(leftDelim):], rightDelim)
+	if end < 0 {
+		return len(src)
+	}
+	return start + len(leftDelim) + end + len(rightDelim)
+}
+
+// tagEnd returns the index after the tag starting at start, the template actions and quoted attribute values of the
+// tag may contain '>'.
+func tagEnd(src string, start int, leftDelim, rightDelim string) (int, bool) {
+	var quote byte
+	for k := start + 1; k < len(src); {
+		switch {
+		case strings.HasPrefix(src[k:], leftDelim):
+			k = actionEnd(src, k, leftDelim, rightDelim)
+			continue
+		case quote != 0:
+			if src[k] == quote {
+				quote = 0
+			}
+		case src[k] == '"' || src[k] == '\'':
+			quote = src[k]
+		case src[k] == '<':
+			return 0, false
+		case src[k] == '>':
+			return k + 1, true
+		}
+		k++
+	}
+	return 0, false
+}
+
+// allowedTag reports whether the tag and its attributes are allowed, with the name of the tag and whether it is a
+// closing tag.
+func allowedTag(tag, leftDelim, rightDelim string, tags map[string][]string) (string, bool, bool) {
+	// the actions are replaced by NUL so the values given by the template data are recognized.
+	var sb strings.Builder
+	for k := 0; k < len(tag); {
+		if strings.HasPrefix(tag[k:], leftDelim) {
+			k = actionEnd(tag, k, leftDelim, rightDelim)
+			sb.WriteByte(0)
+			continue
+		}
+		sb.WriteByte(tag[k])
+		k++
+	}
+	matches := htmlTag.FindStringSubmatch(sb.String())
+	if matches == nil {
+		return "", false, false
+	}
+	name := strings.ToLower(matches[2])
+	attributes, ok := tags[name]
+	if !ok {
+		return "", false, false
+	}
+	rest := strings.TrimSuffix(strings.TrimSpace(matches[3]), "/")
+	if matches[1] == "/" {
+		return name, true, rest == ""
+	}
+	for _, attribute := range htmlAttribute.FindAllStringSubmatch(rest, -1) {
+		attributeName := strings.ToLower(attribute[1])
+		allowed := false
+		for _, a := range attributes {
+			if a == attributeName {
+				allowed = true
+			}
+		}
+		if !allowed || strings.ContainsRune(attributeName, 0) {
+			return "", false, false
+		}
+		if urlAttributes[attributeName] && !safeTemplateURL(html.UnescapeString(strings.Trim(attribute[2], `"'`))) {
+			return "", false, false
+		}
+	}
+	return name, false, true
+}
+
+// safeTemplateURL reports whether the URL with its actions replaced by NUL is safe, the literal text before the first
+// action must be safe, as well as the literal text of the URL without the actions, so an action can neither end
+// an unsafe scheme nor start a scheme completed by the literal text.
+func safeTemplateURL(u string) bool {
+	literal, _, _ := strings.Cut(u, "\x00")
+	return safeURL(literal) && safeURL(strings.ReplaceAll(u, "\x00", ""))
+}
+
+// safeURL reports whether the literal URL is relative or has a safe scheme.
+func safeURL(u string) bool {
+	u = strings.TrimSpace(u)
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.ContainsAny(u[:colon], "/?#") {
+		return true
+	}
+	return safeSchemes[strings.ToLower(u[:colon])]
+}
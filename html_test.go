@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_HTML(t *testing.T) {
+	t.Run("escape data", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "greeting", Other: "Hello, <b>{{.name}}</b>!"}),
+				Message(&i18n.Message{ID: "profile", Other: `<a href="{{.url}}" title="{{.name}}">Profile</a>`}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, htmltemplate.HTML("Hello, <b>&lt;script&gt;</b>!"), i.HTML("greeting", "name", "<script>"))
+			assert.Equal(t, htmltemplate.HTML(`<a href="#ZgotmplZ" title="&#34;Bob&#34;">Profile</a>`),
+				i.HTML("profile", "url", "javascript:alert(1)", "name", `"Bob"`))
+			assert.Equal(t, htmltemplate.HTML(`<a href="/users?name=a&amp;b" title="Bob">Profile</a>`),
+				i.HTML("profile", "url", "/users?name=a&b", "name", "Bob"))
+			assert.Equal(t, htmltemplate.HTML("missing &lt;id&gt;"), i.HTML("missing <id>"))
+		}
+	})
+
+	t.Run("allowed tags", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "script", Other: "<script>alert(1)</script> <em>done</em>"}),
+				Message(&i18n.Message{ID: "onclick", Other: `<b onclick="alert(1)">bold</b>`}),
+				Message(&i18n.Message{ID: "javascript", Other: `<a href="javascript:alert(1)">link</a>`}),
+				Message(&i18n.Message{ID: "link", Other: `<a href="https://example.com">link</a><br/>`}),
+				Message(&i18n.Message{ID: "compare", Other: "1 < 2"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, htmltemplate.HTML("&lt;script>alert(1)&lt;/script> <em>done</em>"), i.HTML("script"))
+			assert.Equal(t, htmltemplate.HTML(`&lt;b onclick="alert(1)">bold&lt;/b>`), i.HTML("onclick"))
+			assert.Equal(t, htmltemplate.HTML(`&lt;a href="javascript:alert(1)">link&lt;/a>`), i.HTML("javascript"))
+			assert.Equal(t, htmltemplate.HTML(`<a href="https://example.com">link</a><br/>`), i.HTML("link"))
+			assert.Equal(t, htmltemplate.HTML("1 &lt; 2"), i.HTML("compare"))
+			i.SetHTMLTags(map[string][]string{"b": {"onclick"}})
+			assert.Equal(t, htmltemplate.HTML(`<b onclick="alert(1)">bold</b>`), i.HTML("onclick"))
+			assert.Equal(t, htmltemplate.HTML("&lt;script>alert(1)&lt;/script> &lt;em>done&lt;/em>"), i.HTML("script"))
+		}
+	})
+
+	t.Run("urls with actions", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "prefix", Other: `<a href="javascript:alert(1)//{{.x}}">go</a>`}),
+				Message(&i18n.Message{ID: "suffix", Other: `<a href="{{.x}}javascript:alert(1)">go</a>`}),
+				Message(&i18n.Message{ID: "scheme", Other: `<a href="{{.x}}script:alert(1)">go</a>`}),
+				Message(&i18n.Message{ID: "entity", Other: `<a href="javascript&#58;alert(1)//{{.x}}">go</a>`}),
+				Message(&i18n.Message{ID: "path", Other: `<a href="https://example.com/{{.x}}">go</a>`}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, htmltemplate.HTML(`&lt;a href="javascript:alert(1)//y">go&lt;/a>`), i.HTML("prefix", "x", "y"))
+			assert.Equal(t, htmltemplate.HTML(`&lt;a href="javascript:alert(1)">go&lt;/a>`), i.HTML("suffix", "x", ""))
+			assert.Equal(t, htmltemplate.HTML(`&lt;a href="javascript:alert(1)">go&lt;/a>`), i.HTML("scheme", "x", "java"))
+			assert.Equal(t, htmltemplate.HTML(`&lt;a href="javascript&#58;alert(1)//y">go&lt;/a>`), i.HTML("entity", "x", "y"))
+			assert.Equal(t, htmltemplate.HTML(`<a href="https://example.com/y">go</a>`), i.HTML("path", "x", "y"))
+		}
+	})
+
+	t.Run("closing tags", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "unopened", Other: "</b>text</em>"}),
+				Message(&i18n.Message{ID: "nested", Other: `<b><a href="javascript:x">a</a></b>`}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, htmltemplate.HTML("&lt;/b>text&lt;/em>"), i.HTML("unopened"))
+			assert.Equal(t, htmltemplate.HTML(`<b>&lt;a href="javascript:x">a&lt;/a></b>`), i.HTML("nested"))
+		}
+	})
+
+	t.Run("plural and reference", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "brand", Other: "<strong>Acme</strong>"}),
+				Message(&i18n.Message{ID: "items", One: "<b>{{.PluralCount}}</b> item in {{t \"brand\"}}", Other: "<b>{{.PluralCount}}</b> items in {{t \"brand\"}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, htmltemplate.HTML("<b>1</b> item in <strong>Acme</strong>"), i.HTMLP("items", 1))
+			assert.Equal(t, htmltemplate.HTML("<b>3</b> items in <strong>Acme</strong>"), i.HTMLP("items", 3))
+		}
+	})
+
+	t.Run("pseudo-locale", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{ID: "greeting", Other: "Hello, <b>{{.name}}</b>"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			i.AddPseudoLocale("en-XA", PseudoLocale{Prefix: "[", Suffix: "]", Transform: accentText})
+			l := i.Locale("en-XA").(*I18n)
+			assert.Equal(t, htmltemplate.HTML("[Ĥéļļö, <b>&lt;i&gt;</b>]"), l.HTML("greeting", "name", "<i>"))
+		}
+	})
+
+	t.Run("func map", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "greeting", Other: "Hello, <b>{{.name}}</b>!"}),
+				Message(&i18n.Message{ID: "items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("fr", Message(&i18n.Message{ID: "greeting", Other: "Bonjour, <b>{{.name}}</b> !"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			view := htmltemplate.Must(htmltemplate.New("view").Funcs(i.FuncMap()).
+				Parse(`<p title="{{t "greeting" "name" .Name}}">{{t "greeting" "name" .Name}} {{tp "items" .Count}}</p>`))
+			var buf bytes.Buffer
+			if err := view.Execute(&buf, map[string]any{"Name": "<Bob>", "Count": 2}); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, `<p title="Hello, &lt;Bob&gt;!">Hello, <b>&lt;Bob&gt;</b>! 2 items</p>`, buf.String())
+			buf.Reset()
+			view = htmltemplate.Must(htmltemplate.New("view").Funcs(i.Locale("fr").(*I18n).FuncMap()).
+				Parse(`<p>{{t "greeting" "name" .Name}}</p>`))
+			if err := view.Execute(&buf, map[string]any{"Name": "Bob"}); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "<p>Bonjour, <b>Bob</b> !</p>", buf.String())
+		}
+	})
+}
@@ -1,16 +1,21 @@
 package i18n
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
+	"os"
+	"slices"
+	"sync/atomic"
 
 	"github.com/gopi-frame/collection/kv"
 
 	"github.com/gopi-frame/contract/translator"
 	"github.com/gopi-frame/exception"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
 	"golang.org/x/text/language"
 )
 
@@ -39,8 +44,22 @@ func SetDefaultMessages(messages map[string]string) {
 
 // I18n is a wrapper around [i18n.Bundle] and an implementation of [translator.Translator].
 type I18n struct {
-	bundle    *i18n.Bundle
-	localizer *i18n.Localizer
+	bundle        *i18n.Bundle
+	localizer     *i18n.Localizer
+	publicKeys    []ed25519.PublicKey
+	tags          []language.Tag
+	versions      *atomic.Uint64
+	resolved      *atomic.Pointer[resolution]
+	lazy          *lazyRegistry
+	pseudo        *pseudoRegistry
+	catalog       *messageCatalog
+	prefix        string
+	refs          []string
+	htmlTags      map[string][]string
+	escapeHTML    bool
+	bidiIsolation bool
+
+	unmarshalFuncs map[string]i18n.UnmarshalFunc
 }
 
 // New creates a new i18n instance with the given default language.
@@ -52,6 +71,13 @@ func New(defaultLanguage string) (*I18n, error) {
 	i := new(I18n)
 	i.bundle = i18n.NewBundle(languageTag)
 	i.localizer = i18n.NewLocalizer(i.bundle, defaultLanguage)
+	i.tags = []language.Tag{languageTag}
+	i.lazy = newLazyRegistry()
+	i.pseudo = newPseudoRegistry()
+	i.catalog = newMessageCatalog()
+	i.unmarshalFuncs = make(map[string]i18n.UnmarshalFunc)
+	i.versions = new(atomic.Uint64)
+	i.resolved = new(atomic.Pointer[resolution])
 	return i, nil
 }
 
@@ -59,23 +85,12 @@ func New(defaultLanguage string) (*I18n, error) {
 // If the length of data is even, it will be used as key-value pairs.
 // If the length of data is greater than 1 and is odd, it will be used as a slice.
 func (i *I18n) T(id string, data ...any) string {
+	id = i.prefix + id
 	lc := &i18n.LocalizeConfig{
-		MessageID: id,
-	}
-	if len(data) > 1 {
-		if len(data)%2 == 0 {
-			var d = make(map[any]any, len(data)/2)
-			for i := 0; i < len(data); i += 2 {
-				d[data[i]] = data[i+1]
-			}
-			lc.TemplateData = d
-		} else {
-			lc.TemplateData = data
-		}
-	} else if len(data) == 1 {
-		lc.TemplateData = data[0]
+		MessageID:    id,
+		TemplateData: templateData(data),
 	}
-	r, err := i.localizer.Localize(lc)
+	r, err := i.localize(lc)
 	if err != nil {
 		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
 			return defaultMessage
@@ -86,27 +101,17 @@ func (i *I18n) T(id string, data ...any) string {
 }
 
 // P returns the translation for the given id and plural count.
+// The plural count is the "PluralCount" template data, it is added to the key-value pairs unless it is given.
 // If the length of data is even, it will be used as key-value pairs.
 // If the length of data is greater than 1 and is odd, it will be used as a slice.
 func (i *I18n) P(id string, pluralCount any, data ...any) string {
+	id = i.prefix + id
 	lc := &i18n.LocalizeConfig{
-		MessageID:   id,
-		PluralCount: pluralCount,
+		MessageID:    id,
+		PluralCount:  pluralCount,
+		TemplateData: pluralTemplateData(data, pluralCount),
 	}
-	if len(data) > 1 {
-		if len(data)%2 == 0 {
-			var d = make(map[any]any, len(data)/2)
-			for i := 0; i < len(data); i += 2 {
-				d[data[i]] = data[i+1]
-			}
-			lc.TemplateData = d
-		} else {
-			lc.TemplateData = data
-		}
-	} else if len(data) == 1 {
-		lc.TemplateData = data[0]
-	}
-	r, err := i.localizer.Localize(lc)
+	r, err := i.localize(lc)
 	if err != nil {
 		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
 			return defaultMessage
@@ -121,54 +126,262 @@ func (i *I18n) P(id string, pluralCount any, data ...any) string {
 // If the length of data is greater than 1 and is odd, it will be used as a slice.
 func (i *I18n) M(message translator.Message, pluralCount any, data ...any) string {
 	lc := &i18n.LocalizeConfig{
-		DefaultMessage: &i18n.Message{
-			ID:          message.GetID(),
-			Hash:        message.GetHash(),
-			Description: message.GetDescription(),
-			LeftDelim:   message.GetLeftDelim(),
-			RightDelim:  message.GetRightDelim(),
-			Zero:        message.GetZero(),
-			One:         message.GetOne(),
-			Two:         message.GetTwo(),
-			Few:         message.GetFew(),
-			Many:        message.GetMany(),
-			Other:       message.GetOther(),
-		},
-		PluralCount: pluralCount,
+		DefaultMessage: toI18nMessage(message),
+		PluralCount:    pluralCount,
+		TemplateData:   pluralTemplateData(data, pluralCount),
+	}
+	id := i.prefix + message.GetID()
+	if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+		lc.DefaultMessage = &i18n.Message{
+			ID:    id,
+			Other: defaultMessage,
+		}
+	} else {
+		lc.DefaultMessage = &i18n.Message{
+			ID:    id,
+			Other: id,
+		}
+	}
+	r, err := i.localize(lc)
+	if err != nil {
+		panic(err)
 	}
+	return r
+}
+
+// templateData returns the template data of the arguments of T, P and M.
+// If the length of data is even, it will be used as key-value pairs.
+// If the length of data is greater than 1 and is odd, it will be used as a slice.
+func templateData(data []any) any {
 	if len(data) > 1 {
 		if len(data)%2 == 0 {
 			var d = make(map[any]any, len(data)/2)
 			for i := 0; i < len(data); i += 2 {
 				d[data[i]] = data[i+1]
 			}
-			lc.TemplateData = d
-		} else {
-			lc.TemplateData = data
+			return d
 		}
+		return data
 	} else if len(data) == 1 {
-		lc.TemplateData = data[0]
+		return data[0]
 	}
-	if defaultMessage := GetDefaultMessage(message.GetID()); defaultMessage != "" {
-		lc.DefaultMessage = &i18n.Message{
-			ID:    message.GetID(),
-			Other: defaultMessage,
+	return nil
+}
+
+// pluralTemplateData returns the template data of the arguments of P and the other plural translations,
+// the plural count is added to the key-value pairs as "PluralCount" unless it is given.
+func pluralTemplateData(data []any, pluralCount any) any {
+	d := templateData(data)
+	if pluralCount == nil {
+		return d
+	}
+	switch m := d.(type) {
+	case map[any]any:
+		if _, ok := m["PluralCount"]; !ok {
+			d := make(map[any]any, len(m)+1)
+			for k, v := range m {
+				d[k] = v
+			}
+			d["PluralCount"] = pluralCount
+			return d
 		}
+	case map[string]any:
+		if _, ok := m["PluralCount"]; !ok {
+			d := make(map[string]any, len(m)+1)
+			for k, v := range m {
+				d[k] = v
+			}
+			d["PluralCount"] = pluralCount
+			return d
+		}
+	}
+	return d
+}
+
+// resolution is the language resolved by a translator for the languages registered at a version.
+type resolution struct {
+	version uint64
+	// tag is the language resolved among the languages of the bundle and the lazily registered languages,
+	// or the pseudo-locale wanted by its exact tag.
+	tag       language.Tag
+	direction Direction
+	// bundleTag is the language resolved among the languages of the bundle.
+	bundleTag language.Tag
+	lazy      *lazySource
+	pseudo    *PseudoLocale
+	// pseudoLocalizer is the localizer of the default language rendered with the pseudo-locale.
+	pseudoLocalizer *i18n.Localizer
+}
+
+// resolve returns the languages resolved by the translator, they are resolved again when languages are added.
+func (i *I18n) resolve() *resolution {
+	version := i.versions.Load()
+	if r := i.resolved.Load(); r != nil && r.version == version {
+		return r
+	}
+	r := &resolution{version: version}
+	bundleTags := i.bundle.LanguageTags()
+	_, index, _ := language.NewMatcher(bundleTags).Match(i.tags...)
+	r.bundleTag = bundleTags[index]
+	tags := append(append([]language.Tag{}, bundleTags...), i.lazy.languageTags()...)
+	if pseudo, tag, ok := i.pseudo.match(tags, i.tags); ok {
+		r.tag = tag
+		r.pseudo = &pseudo
+		r.pseudoLocalizer = i18n.NewLocalizer(i.bundle, bundleTags[0].String())
 	} else {
-		lc.DefaultMessage = &i18n.Message{
-			ID:    message.GetID(),
-			Other: message.GetID(),
+		_, index, _ := language.NewMatcher(tags).Match(i.tags...)
+		r.tag = tags[index]
+		r.lazy = i.lazy.source(r.tag)
+	}
+	r.direction = directionOf(r.tag)
+	i.resolved.Store(r)
+	return r
+}
+
+// languagesChanged makes the translators resolve their languages again.
+func (i *I18n) languagesChanged() {
+	i.versions.Add(1)
+}
+
+// matchedTag returns the language resolved by the translator among the languages of the bundle and
+// the lazily registered languages, or the pseudo-locale wanted by its exact tag.
+func (i *I18n) matchedTag() language.Tag {
+	return i.resolve().tag
+}
+
+// localize localizes the message with the pseudo-locale or the lazily loaded language resolved by the translator
+// first, and falls back to the bundle.
+func (i *I18n) localize(lc *i18n.LocalizeConfig) (string, error) {
+	return i.localizeForm(lc, nil)
+}
+
+// localizeForm localizes the message in the languages resolved by the translator, the plural form is selected by the
+// localizer, or by form for the resolved language if it is not nil.
+func (i *I18n) localizeForm(lc *i18n.LocalizeConfig, form func(tag language.Tag) plural.Form) (string, error) {
+	if lc.Funcs == nil {
+		id := lc.MessageID
+		if lc.DefaultMessage != nil {
+			id = lc.DefaultMessage.ID
+		}
+		lc.Funcs = i.templateFuncs(id)
+	}
+	if i.escapeHTML && lc.TemplateParser == nil {
+		lc.TemplateParser = &htmlParser{funcs: lc.Funcs, tags: i.htmlTags}
+	}
+	if lc.TemplateParser == nil {
+		lc.TemplateParser = i.textParser(lc.Funcs)
+	}
+	localizations := i.localizations()
+	last := len(localizations) - 1
+	for _, l := range localizations[:last] {
+		config := *lc
+		if lc.DefaultMessage != nil {
+			// the default message is only used by the last language.
+			config.MessageID = lc.DefaultMessage.ID
+			config.DefaultMessage = nil
+		}
+		if r, err := l.localize(&config, form); err == nil {
+			return r, nil
+		}
+	}
+	return localizations[last].localize(lc, form)
+}
+
+// localization is a language resolved by the translator.
+type localization struct {
+	localizer *i18n.Localizer
+	lookup    func(id string) (*i18n.Message, language.Tag, bool)
+	pseudo    *PseudoLocale
+}
+
+// localizations returns the languages resolved by the translator in the order they are tried, the default language
+// with the pseudo-locale, or the lazily loaded language followed by the language of the bundle.
+func (i *I18n) localizations() []localization {
+	r := i.resolve()
+	if r.pseudo != nil {
+		defaultTag := i.bundle.LanguageTags()[0]
+		return []localization{{
+			localizer: r.pseudoLocalizer,
+			lookup: func(id string) (*i18n.Message, language.Tag, bool) {
+				m, ok := i.catalog.get(defaultTag, id)
+				return m, defaultTag, ok
+			},
+			pseudo: r.pseudo,
+		}}
+	}
+	var localizations []localization
+	if l, ok := i.lazyLocalization(); ok {
+		localizations = append(localizations, l)
+	}
+	return append(localizations, localization{
+		localizer: i.localizer,
+		lookup: func(id string) (*i18n.Message, language.Tag, bool) {
+			m, ok := i.catalog.get(r.bundleTag, id)
+			return m, r.bundleTag, ok
+		},
+	})
+}
+
+// localize localizes the message in the language, the plural form is selected by the localizer,
+// or by form if it is not nil.
+func (l localization) localize(lc *i18n.LocalizeConfig, form func(tag language.Tag) plural.Form) (string, error) {
+	if l.pseudo != nil {
+		config := *lc
+		config.TemplateParser = &pseudoParser{parser: lc.TemplateParser, pseudo: *l.pseudo}
+		lc = &config
+	}
+	if form == nil {
+		return l.localizer.Localize(lc)
+	}
+	m, tag, ok := l.lookup(lc.MessageID)
+	if !ok {
+		return "", &i18n.MessageNotFoundErr{Tag: tag, MessageID: lc.MessageID}
+	}
+	data := lc.TemplateData
+	if data == nil {
+		data = map[string]any{"PluralCount": lc.PluralCount}
+	}
+	parsed, err := lc.TemplateParser.Parse(formText(m, form(tag)), m.LeftDelim, m.RightDelim)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Execute(data)
+}
+
+// formText returns the text of the plural form of the message, the other form is used if it is empty.
+func formText(m *i18n.Message, form plural.Form) string {
+	switch form {
+	case plural.Zero:
+		return firstNonEmpty(m.Zero, m.Other)
+	case plural.One:
+		return firstNonEmpty(m.One, m.Other)
+	case plural.Two:
+		return firstNonEmpty(m.Two, m.Other)
+	case plural.Few:
+		return firstNonEmpty(m.Few, m.Other)
+	case plural.Many:
+		return firstNonEmpty(m.Many, m.Other)
+	}
+	return m.Other
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
 		}
 	}
-	return i.localizer.MustLocalize(lc)
+	return ""
 }
 
 // Locale returns a translator for the given languages.
 func (i *I18n) Locale(languages ...string) translator.Translator {
-	l := new(I18n)
-	l.bundle = i.bundle
+	l := *i
 	l.localizer = i18n.NewLocalizer(i.bundle, languages...)
-	return l
+	l.tags = parseLanguages(languages)
+	l.resolved = new(atomic.Pointer[resolution])
+	l.lazyLocalizer()
+	return &l
 }
 
 // AddMessages adds messages to the bundle.
@@ -184,30 +397,39 @@ func (i *I18n) AddMessages(l string, messages ...translator.Message) error {
 func (i *I18n) AddMessagesByLanguageTag(languageTag language.Tag, messages ...translator.Message) error {
 	var msgList []*i18n.Message
 	for _, message := range messages {
-		msgList = append(msgList, &i18n.Message{
-			ID:          message.GetID(),
-			Hash:        message.GetHash(),
-			Description: message.GetDescription(),
-			LeftDelim:   message.GetLeftDelim(),
-			RightDelim:  message.GetRightDelim(),
-			Zero:        message.GetZero(),
-			One:         message.GetOne(),
-			Two:         message.GetTwo(),
-			Few:         message.GetFew(),
-			Many:        message.GetMany(),
-			Other:       message.GetOther(),
-		})
-	}
-	return i.bundle.AddMessages(languageTag, msgList...)
+		m := toI18nMessage(message)
+		m.ID = i.prefix + m.ID
+		msgList = append(msgList, m)
+	}
+	added := !slices.Contains(i.bundle.LanguageTags(), languageTag)
+	if err := i.bundle.AddMessages(languageTag, msgList...); err != nil {
+		return err
+	}
+	i.catalog.add(languageTag, msgList...)
+	if added {
+		i.languagesChanged()
+	}
+	return nil
 }
 
 // RegisterUnmarshalFunc registers a custom unmarshal function for the given format.
 func (i *I18n) RegisterUnmarshalFunc(format string, unmarshaller func(data []byte, v any) error) {
 	i.bundle.RegisterUnmarshalFunc(format, unmarshaller)
+	i.unmarshalFuncs[format] = unmarshaller
+}
+
+// SetPublicKeys sets the public keys used to verify the message packs.
+// Once set, the content loaded by [I18n.LoadMessage], the remote loaders and the archive entries must be a signed [Envelope]
+// and is rejected unless its signature matches one of the keys.
+func (i *I18n) SetPublicKeys(publicKeys ...ed25519.PublicKey) {
+	i.publicKeys = publicKeys
 }
 
 // LoadMessage loads messages from the given loader and parser.
 func (i *I18n) LoadMessage(loader translator.Loader, parser translator.Parser) error {
+	if len(i.publicKeys) > 0 {
+		loader = VerifiedLoader(loader, i.publicKeys...)
+	}
 	content, err := loader.Load()
 	if err != nil {
 		return err
@@ -221,14 +443,20 @@ func (i *I18n) LoadMessage(loader translator.Loader, parser translator.Parser) e
 
 // LoadMessageFile loads messages from a file.
 func (i *I18n) LoadMessageFile(path string) error {
-	_, err := i.bundle.LoadMessageFile(path)
-	return err
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return i.loadMessageFileBytes(content, path, "")
 }
 
 // LoadMessageFileFS loads messages from a file from the given file system.
 func (i *I18n) LoadMessageFileFS(fsys fs.FS, path string) error {
-	_, err := i.bundle.LoadMessageFileFS(fsys, path)
-	return err
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+	return i.loadMessageFileBytes(content, path, "")
 }
 
 // LoadMessageRemote loads messages from a remote url.
@@ -269,7 +497,27 @@ func (i *I18n) LoadMessageRemoteRequest(req *http.Request, parser translator.Par
 	}), parser)
 }
 
-// LanguageTags returns the list of language tags of the bundle.
+// LanguageTags returns the list of language tags of the bundle,
+// followed by the lazily registered languages which are not loaded yet.
 func (i *I18n) LanguageTags() []language.Tag {
-	return i.bundle.LanguageTags()
+	tags := append([]language.Tag{}, i.bundle.LanguageTags()...)
+	for _, tag := range i.lazy.languageTags() {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseLanguages parses the languages the same way as [i18n.NewLocalizer].
+func parseLanguages(languages []string) []language.Tag {
+	var tags []language.Tag
+	for _, l := range languages {
+		t, _, err := language.ParseAcceptLanguage(l)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, t...)
+	}
+	return tags
 }
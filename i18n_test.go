@@ -150,6 +150,7 @@ func TestI18n_M(t *testing.T) {
 			assert.Equal(t, "test one", message)
 		}
 	})
+
 }
 
 func TestI18n_Locale(t *testing.T) {
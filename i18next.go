@@ -0,0 +1,180 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// i18nextMaxNesting is the max depth of $t(key) references resolved by [I18nextParser].
+const i18nextMaxNesting = 10
+
+var (
+	i18nextInterpolation = regexp.MustCompile(`\{\{-?\s*([^}]+?)\s*\}\}`)
+	i18nextNesting       = regexp.MustCompile(`\$t\(\s*([^,)]+?)\s*(?:,[^)]*)?\)`)
+	templateField        = regexp.MustCompile(`\{\{-?\s*\.([\w.]+)\s*-?\}\}`)
+)
+
+// I18nextParser returns a parser of i18next JSON v4 resources.
+//
+// Keys with the plural suffixes _zero, _one, _two, _few, _many and _other are merged into a single message
+// with the corresponding plural forms, {{name}} interpolations are converted to {{.name}} template fields,
+// and $t(key) references to messages of the same resource are replaced by the referenced text.
+// The i18next {{count}} interpolation is the plural count, it is converted to {{.PluralCount}} as given by [I18n.P].
+// Keys with a context suffix, for example friend_male and friend_male_one, are the select variants of [I18n.S].
+func I18nextParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		flat := make(map[string]string)
+		if err := flattenI18next("", raw, flat); err != nil {
+			return nil, err
+		}
+		messages := make(map[string]*i18n.Message)
+		var ids []string
+		for key, value := range flat {
+			id, form := splitPluralSuffix(key)
+			m, ok := messages[id]
+			if !ok {
+				m = &i18n.Message{ID: id}
+				messages[id] = m
+				ids = append(ids, id)
+			}
+			if form == "" {
+				if m.Other == "" {
+					m.Other = value
+				}
+				continue
+			}
+			setMessageField(m, form, value)
+		}
+		sort.Strings(ids)
+		var pack []translator.Message
+		for _, id := range ids {
+			m := messages[id]
+			for _, field := range messageForms(m) {
+				*field = convertI18nextText(*field, flat, 0)
+			}
+			pack = append(pack, Message(m))
+		}
+		return MessagePack(pack, tag), nil
+	})
+}
+
+// ExportI18next encodes the message pack to i18next JSON v4 resources, it is the inverse of [I18nextParser].
+func ExportI18next(messagePack translator.MessagePack) ([]byte, error) {
+	root := make(map[string]any)
+	for _, message := range messagePack.GetMessages() {
+		forms := map[string]string{
+			"zero": message.GetZero(),
+			"one":  message.GetOne(),
+			"two":  message.GetTwo(),
+			"few":  message.GetFew(),
+			"many": message.GetMany(),
+		}
+		plural := false
+		for form, text := range forms {
+			if text == "" {
+				continue
+			}
+			plural = true
+			if err := setNested(root, message.GetID()+"_"+form, toI18nextText(text)); err != nil {
+				return nil, err
+			}
+		}
+		key := message.GetID()
+		if plural {
+			key += "_other"
+		}
+		if err := setNested(root, key, toI18nextText(message.GetOther())); err != nil {
+			return nil, err
+		}
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func flattenI18next(prefix string, raw map[string]any, flat map[string]string) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flattenI18next(key, v, flat); err != nil {
+				return err
+			}
+		case string:
+			flat[key] = v
+		case []any, nil:
+			return exception.New(fmt.Sprintf("unsupported value for key %q", key))
+		default:
+			flat[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+func splitPluralSuffix(key string) (id, form string) {
+	index := strings.LastIndex(key, "_")
+	if index <= 0 {
+		return key, ""
+	}
+	for _, pluralKey := range pluralKeys {
+		if key[index+1:] == pluralKey {
+			return key[:index], pluralKey
+		}
+	}
+	return key, ""
+}
+
+func messageForms(m *i18n.Message) []*string {
+	return []*string{&m.Zero, &m.One, &m.Two, &m.Few, &m.Many, &m.Other}
+}
+
+// convertI18nextText resolves the $t(key) references and converts the interpolations to template fields,
+// the count is converted to the PluralCount field.
+func convertI18nextText(text string, flat map[string]string, depth int) string {
+	if depth < i18nextMaxNesting {
+		text = i18nextNesting.ReplaceAllStringFunc(text, func(ref string) string {
+			key := i18nextNesting.FindStringSubmatch(ref)[1]
+			referenced, ok := flat[key]
+			if !ok {
+				if referenced, ok = flat[key+"_other"]; !ok {
+					return ref
+				}
+			}
+			return convertI18nextText(referenced, flat, depth+1)
+		})
+	}
+	return i18nextInterpolation.ReplaceAllStringFunc(text, func(interpolation string) string {
+		name := i18nextInterpolation.FindStringSubmatch(interpolation)[1]
+		if strings.HasPrefix(name, ".") {
+			return interpolation
+		}
+		name, _, _ = strings.Cut(name, ",")
+		if name = strings.TrimSpace(name); name == "count" {
+			name = "PluralCount"
+		}
+		return "{{." + name + "}}"
+	})
+}
+
+// toI18nextText converts the template fields to i18next interpolations.
+func toI18nextText(text string) string {
+	return templateField.ReplaceAllStringFunc(text, func(field string) string {
+		name := templateField.FindStringSubmatch(field)[1]
+		if name == "PluralCount" {
+			name = "count"
+		}
+		return "{{" + name + "}}"
+	})
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestI18nextParser(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+			return []byte(`{
+				"brand": "Gopi",
+				"welcome": "Welcome to $t(brand), {{name}}!",
+				"cart": {
+					"items_one": "{{count}} item",
+					"items_other": "{{count}} items"
+				}
+			}`), nil
+		}), I18nextParser(language.English))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "Welcome to Gopi, world!", i.T("welcome", map[string]any{"name": "world"}))
+		assert.Equal(t, "1 item", i.P("cart.items", 1))
+		assert.Equal(t, "2 items", i.P("cart.items", 2))
+		assert.Equal(t, "3 items", i.P("cart.items", 3, "name", "world"))
+	}
+}
+
+func TestExportI18next(t *testing.T) {
+	content, err := ExportI18next(MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "welcome", Other: "Welcome, {{.name}}!"}),
+		Message(&i18n.Message{ID: "cart.items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"}),
+	}, language.English))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.JSONEq(t, `{"welcome": "Welcome, {{name}}!", "cart": {"items_one": "{{count}} item", "items_other": "{{count}} items"}}`, string(content))
+
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		if err := i.LoadMessage(LoaderFunc(func() ([]byte, error) { return content, nil }), I18nextParser(language.English)); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "5 items", i.P("cart.items", 5))
+	}
+}
@@ -0,0 +1,323 @@
+package i18n
+
+import (
+	"crypto/ed25519"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// LocaleUsage is the usage of a lazily loaded language.
+type LocaleUsage struct {
+	Tag      language.Tag
+	LoadedAt time.Time
+	LastUsed time.Time
+}
+
+// EvictionPolicy decides which lazily loaded languages should be unloaded.
+type EvictionPolicy interface {
+	// Evict returns the tags to unload from the given usages of the loaded languages.
+	Evict(usages []LocaleUsage) []language.Tag
+}
+
+// EvictionPolicyFunc is a function implementing [EvictionPolicy].
+type EvictionPolicyFunc func(usages []LocaleUsage) []language.Tag
+
+// Evict calls f(usages).
+func (f EvictionPolicyFunc) Evict(usages []LocaleUsage) []language.Tag {
+	return f(usages)
+}
+
+// IdleEvictionPolicy returns an [EvictionPolicy] which unloads the languages not used for the given duration.
+func IdleEvictionPolicy(idle time.Duration) EvictionPolicy {
+	return EvictionPolicyFunc(func(usages []LocaleUsage) []language.Tag {
+		var tags []language.Tag
+		deadline := time.Now().Add(-idle)
+		for _, usage := range usages {
+			if usage.LastUsed.Before(deadline) {
+				tags = append(tags, usage.Tag)
+			}
+		}
+		return tags
+	})
+}
+
+// LRUEvictionPolicy returns an [EvictionPolicy] which keeps at most size languages loaded,
+// the least recently used languages are unloaded first.
+func LRUEvictionPolicy(size int) EvictionPolicy {
+	return EvictionPolicyFunc(func(usages []LocaleUsage) []language.Tag {
+		if len(usages) <= size {
+			return nil
+		}
+		sort.Slice(usages, func(i, j int) bool {
+			return usages[i].LastUsed.Before(usages[j].LastUsed)
+		})
+		var tags []language.Tag
+		for _, usage := range usages[:len(usages)-size] {
+			tags = append(tags, usage.Tag)
+		}
+		return tags
+	})
+}
+
+// lazyRetryDelay is the delay before a failed lazy load is retried, it doubles after each failure up to
+// lazyMaxRetryDelay.
+var (
+	lazyRetryDelay    = time.Second
+	lazyMaxRetryDelay = 5 * time.Minute
+)
+
+// lazyFailure is the last failed load of a lazy source.
+type lazyFailure struct {
+	err      error
+	at       time.Time
+	attempts int
+}
+
+// retrying reports whether the load can be retried.
+func (f *lazyFailure) retrying() bool {
+	delay := lazyRetryDelay << (f.attempts - 1)
+	if delay < lazyRetryDelay || delay > lazyMaxRetryDelay {
+		delay = lazyMaxRetryDelay
+	}
+	return time.Since(f.at) >= delay
+}
+
+type lazySource struct {
+	tag       language.Tag
+	loader    translator.Loader
+	parser    translator.Parser
+	mu        sync.Mutex
+	localizer atomic.Pointer[i18n.Localizer]
+	messages  atomic.Pointer[map[string]*i18n.Message]
+	failure   atomic.Pointer[lazyFailure]
+	loadedAt  atomic.Int64
+	lastUsed  atomic.Int64
+}
+
+// get returns the localizer of the source, the messages are loaded on the first call.
+// Concurrent calls wait for the same load, a failed load is reported without loading again until it can be retried.
+func (s *lazySource) get(publicKeys []ed25519.PublicKey) (*i18n.Localizer, error) {
+	s.lastUsed.Store(time.Now().UnixNano())
+	if localizer := s.localizer.Load(); localizer != nil {
+		return localizer, nil
+	}
+	if failure := s.failure.Load(); failure != nil && !failure.retrying() {
+		return nil, failure.err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if localizer := s.localizer.Load(); localizer != nil {
+		return localizer, nil
+	}
+	failure := s.failure.Load()
+	if failure != nil && !failure.retrying() {
+		return nil, failure.err
+	}
+	localizer, err := s.load(publicKeys)
+	if err != nil {
+		attempts := 1
+		if failure != nil {
+			attempts = failure.attempts + 1
+		}
+		s.failure.Store(&lazyFailure{err: err, at: time.Now(), attempts: attempts})
+		return nil, err
+	}
+	s.failure.Store(nil)
+	return localizer, nil
+}
+
+// load loads the messages of the source.
+func (s *lazySource) load(publicKeys []ed25519.PublicKey) (*i18n.Localizer, error) {
+	loader := s.loader
+	if len(publicKeys) > 0 {
+		loader = VerifiedLoader(loader, publicKeys...)
+	}
+	content, err := loader.Load()
+	if err != nil {
+		return nil, err
+	}
+	messagePack, err := s.parser.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	bundle := i18n.NewBundle(s.tag)
+	messages := make(map[string]*i18n.Message)
+	for _, message := range messagePack.GetMessages() {
+		m := toI18nMessage(message)
+		if err := bundle.AddMessages(s.tag, m); err != nil {
+			return nil, err
+		}
+		messages[m.ID] = m
+	}
+	localizer := i18n.NewLocalizer(bundle, s.tag.String())
+	s.loadedAt.Store(time.Now().UnixNano())
+	s.messages.Store(&messages)
+	s.localizer.Store(localizer)
+	return localizer, nil
+}
+
+func (s *lazySource) unload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.localizer.Store(nil)
+	s.messages.Store(nil)
+}
+
+type lazyRegistry struct {
+	mu      sync.RWMutex
+	sources map[language.Tag]*lazySource
+	tags    []language.Tag
+	policy  EvictionPolicy
+}
+
+func newLazyRegistry() *lazyRegistry {
+	return &lazyRegistry{
+		sources: make(map[language.Tag]*lazySource),
+	}
+}
+
+func (r *lazyRegistry) add(tag language.Tag, loader translator.Loader, parser translator.Parser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.sources[tag]; ok {
+		old.unload()
+	} else {
+		r.tags = append(r.tags, tag)
+	}
+	r.sources[tag] = &lazySource{
+		tag:    tag,
+		loader: loader,
+		parser: parser,
+	}
+}
+
+// source returns the source of the language, it returns nil when the language is not registered lazily.
+func (r *lazyRegistry) source(tag language.Tag) *lazySource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sources[tag]
+}
+
+func (r *lazyRegistry) languageTags() []language.Tag {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]language.Tag{}, r.tags...)
+}
+
+func (r *lazyRegistry) evict() {
+	r.mu.RLock()
+	policy := r.policy
+	var usages []LocaleUsage
+	for _, tag := range r.tags {
+		source := r.sources[tag]
+		if source.localizer.Load() == nil {
+			continue
+		}
+		usages = append(usages, LocaleUsage{
+			Tag:      tag,
+			LoadedAt: time.Unix(0, source.loadedAt.Load()),
+			LastUsed: time.Unix(0, source.lastUsed.Load()),
+		})
+	}
+	r.mu.RUnlock()
+	if policy == nil || len(usages) == 0 {
+		return
+	}
+	for _, tag := range policy.Evict(usages) {
+		r.mu.RLock()
+		source, ok := r.sources[tag]
+		r.mu.RUnlock()
+		if ok {
+			source.unload()
+		}
+	}
+}
+
+// AddLazySource registers a loader and parser for the given language.
+// The messages are not loaded until the language is first resolved by [I18n.Locale]
+// or by the fallback to the default language, concurrent resolutions share a single load.
+func (i *I18n) AddLazySource(l string, loader translator.Loader, parser translator.Parser) error {
+	languageTag, err := language.Parse(l)
+	if err != nil {
+		return err
+	}
+	i.AddLazySourceByLanguageTag(languageTag, loader, parser)
+	return nil
+}
+
+// AddLazySourceByLanguageTag registers a loader and parser for the given language tag.
+func (i *I18n) AddLazySourceByLanguageTag(languageTag language.Tag, loader translator.Loader, parser translator.Parser) {
+	i.lazy.add(languageTag, loader, parser)
+	i.languagesChanged()
+}
+
+// SetEvictionPolicy sets the policy deciding which lazily loaded languages are unloaded.
+// The policy is applied after each lazy load and by [I18n.EvictLocales].
+func (i *I18n) SetEvictionPolicy(policy EvictionPolicy) {
+	i.lazy.mu.Lock()
+	defer i.lazy.mu.Unlock()
+	i.lazy.policy = policy
+}
+
+// EvictLocales applies the eviction policy to the lazily loaded languages.
+// Unloaded languages are loaded again on next use.
+func (i *I18n) EvictLocales() {
+	i.lazy.evict()
+}
+
+// lazyLocalizer returns the source of the lazily registered language resolved by the translator and its localizer,
+// loading its messages if needed.
+func (i *I18n) lazyLocalizer() (*lazySource, *i18n.Localizer) {
+	source := i.resolve().lazy
+	if source == nil {
+		return nil, nil
+	}
+	loaded := source.localizer.Load() != nil
+	localizer, err := source.get(i.publicKeys)
+	if err != nil {
+		return nil, nil
+	}
+	if !loaded {
+		i.lazy.evict()
+	}
+	return source, localizer
+}
+
+// LoadErr returns the error of the last failed load of the lazily registered language resolved by the translator,
+// it returns nil if the language is loaded, not loaded yet or not registered lazily.
+// The failed loads are retried on use after a delay doubling after each failure, up to 5 minutes.
+func (i *I18n) LoadErr() error {
+	source := i.resolve().lazy
+	if source == nil {
+		return nil
+	}
+	if failure := source.failure.Load(); failure != nil {
+		return failure.err
+	}
+	return nil
+}
+
+// lazyLocalization returns the lazily registered language resolved by the translator, loading its messages if needed.
+func (i *I18n) lazyLocalization() (localization, bool) {
+	source, localizer := i.lazyLocalizer()
+	if localizer == nil {
+		return localization{}, false
+	}
+	return localization{
+		localizer: localizer,
+		lookup: func(id string) (*i18n.Message, language.Tag, bool) {
+			messages := source.messages.Load()
+			if messages == nil {
+				return nil, source.tag, false
+			}
+			m, ok := (*messages)[id]
+			return m, source.tag, ok
+		},
+	}, true
+}
@@ -0,0 +1,158 @@
+package i18n
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func testLazyLoader(counter *atomic.Int32, content string) translator.Loader {
+	return LoaderFunc(func() ([]byte, error) {
+		counter.Add(1)
+		time.Sleep(time.Millisecond * 10)
+		return []byte(content), nil
+	})
+}
+
+func TestI18n_AddLazySource(t *testing.T) {
+	t.Run("load on first use", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{ID: "test", Other: "test one"}), Message(&i18n.Message{ID: "fallback", Other: "fallback"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			var counter atomic.Int32
+			err = i.AddLazySource("fr", testLazyLoader(&counter, `[{"id": "test", "other": "test un"}]`), testJSONParser())
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "test one", i.T("test"))
+			assert.Equal(t, int32(0), counter.Load())
+
+			var wg sync.WaitGroup
+			for n := 0; n < 10; n++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.Equal(t, "test un", i.Locale("fr-CA").T("test"))
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int32(1), counter.Load())
+			assert.Equal(t, "fallback", i.Locale("fr").T("fallback"))
+		}
+	})
+
+	t.Run("language tags", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			var counter atomic.Int32
+			err = i.AddLazySource("fr", testLazyLoader(&counter, `[{"id": "test", "other": "test un"}]`), testJSONParser())
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, []language.Tag{language.English, language.French}, i.LanguageTags())
+			assert.Equal(t, language.French, i.Languages()[1].Tag)
+			assert.Equal(t, "test un", i.Locale("fr").T("test"))
+			assert.Equal(t, []language.Tag{language.English, language.French}, i.LanguageTags())
+			assert.Equal(t, int32(1), counter.Load())
+		}
+	})
+
+	t.Run("lazy default language", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			var counter atomic.Int32
+			i.AddLazySourceByLanguageTag(language.English, testLazyLoader(&counter, `[{"id": "test", "other": "test one"}]`), testJSONParser())
+			assert.Equal(t, "test one", i.Locale("ja").T("test"))
+			assert.Equal(t, int32(1), counter.Load())
+		}
+	})
+}
+
+func TestI18n_LoadErr(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.AddMessages("en", Message(&i18n.Message{ID: "test", Other: "test one"}))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		var counter atomic.Int32
+		err = i.AddLazySource("fr", LoaderFunc(func() ([]byte, error) {
+			if counter.Add(1) == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return []byte(`[{"id": "test", "other": "test un"}]`), nil
+		}), testJSONParser())
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		fr := i.Locale("fr").(*I18n)
+		assert.Equal(t, "test one", fr.T("test"))
+		assert.Equal(t, "test one", fr.T("test"))
+		assert.Equal(t, int32(1), counter.Load())
+		assert.EqualError(t, fr.LoadErr(), "connection refused")
+		assert.NoError(t, i.LoadErr())
+
+		defer func(delay time.Duration) {
+			lazyRetryDelay = delay
+		}(lazyRetryDelay)
+		lazyRetryDelay = 0
+		assert.Equal(t, "test un", fr.T("test"))
+		assert.Equal(t, int32(2), counter.Load())
+		assert.NoError(t, fr.LoadErr())
+	}
+}
+
+func TestI18n_SetEvictionPolicy(t *testing.T) {
+	t.Run("lru", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			var frCounter, deCounter atomic.Int32
+			i.AddLazySourceByLanguageTag(language.French, testLazyLoader(&frCounter, `[{"id": "test", "other": "test un"}]`), testJSONParser())
+			i.AddLazySourceByLanguageTag(language.German, testLazyLoader(&deCounter, `[{"id": "test", "other": "test eins"}]`), testJSONParser())
+			i.SetEvictionPolicy(LRUEvictionPolicy(1))
+			fr := i.Locale("fr")
+			assert.Equal(t, "test un", fr.T("test"))
+			assert.Equal(t, "test eins", i.Locale("de").T("test"))
+			assert.Equal(t, "test un", fr.T("test"))
+			assert.Equal(t, int32(2), frCounter.Load())
+			assert.Equal(t, int32(1), deCounter.Load())
+		}
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			var counter atomic.Int32
+			i.AddLazySourceByLanguageTag(language.French, testLazyLoader(&counter, `[{"id": "test", "other": "test un"}]`), testJSONParser())
+			i.SetEvictionPolicy(IdleEvictionPolicy(time.Millisecond * 20))
+			fr := i.Locale("fr")
+			time.Sleep(time.Millisecond * 30)
+			i.EvictLocales()
+			assert.Equal(t, int32(1), counter.Load())
+			assert.Equal(t, "test un", fr.T("test"))
+			assert.Equal(t, int32(2), counter.Load())
+		}
+	})
+}
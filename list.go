@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gopi-frame/exception"
+	"golang.org/x/text/language"
+)
+
+// ListStyle is the style of a list formatted by [I18n.List].
+type ListStyle int
+
+const (
+	// ListConjunction joins the items with "and", for example "Alice, Bob, and Carol".
+	ListConjunction ListStyle = iota
+	// ListDisjunction joins the items with "or", for example "Alice, Bob, or Carol".
+	ListDisjunction
+	// ListUnit joins the values of a measurement, for example "5 feet, 2 inches".
+	ListUnit
+)
+
+// listPattern is a CLDR list pattern, {0} and {1} are replaced by the items.
+type listPattern struct {
+	start  string
+	middle string
+	end    string
+	two    string
+}
+
+func (p listPattern) format(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return applyListPattern(p.two, items[0], items[1])
+	}
+	n := len(items)
+	result := applyListPattern(p.end, items[n-2], items[n-1])
+	for k := n - 3; k > 0; k-- {
+		result = applyListPattern(p.middle, items[k], result)
+	}
+	return applyListPattern(p.start, items[0], result)
+}
+
+func applyListPattern(pattern, first, second string) string {
+	return strings.NewReplacer("{0}", first, "{1}", second).Replace(pattern)
+}
+
+// simpleListPattern returns the pattern which joins the items with the separator and the last two with the word.
+func simpleListPattern(separator, last string) listPattern {
+	return listPattern{
+		start:  "{0}" + separator + "{1}",
+		middle: "{0}" + separator + "{1}",
+		end:    "{0}" + last + "{1}",
+		two:    "{0}" + last + "{1}",
+	}
+}
+
+// rootListPatterns are the CLDR root patterns, used for the languages without list patterns.
+var rootListPatterns = [3]listPattern{
+	simpleListPattern(", ", ", "),
+	simpleListPattern(", ", ", "),
+	simpleListPattern(", ", ", "),
+}
+
+// listPatterns are the CLDR list patterns of the conjunction, disjunction and unit styles by base language.
+var listPatterns = map[string][3]listPattern{
+	"en": {
+		{start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, and {1}", two: "{0} and {1}"},
+		{start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, or {1}", two: "{0} or {1}"},
+		simpleListPattern(", ", ", "),
+	},
+	"es": {simpleListPattern(", ", " y "), simpleListPattern(", ", " o "), simpleListPattern(", ", " y ")},
+	"fr": {simpleListPattern(", ", " et "), simpleListPattern(", ", " ou "), simpleListPattern(", ", " et ")},
+	"de": {
+		simpleListPattern(", ", " und "),
+		simpleListPattern(", ", " oder "),
+		{start: "{0}, {1}", middle: "{0}, {1}", end: "{0} und {1}", two: "{0}, {1}"},
+	},
+	"it": {simpleListPattern(", ", " e "), simpleListPattern(", ", " o "), simpleListPattern(", ", " e ")},
+	"pt": {simpleListPattern(", ", " e "), simpleListPattern(", ", " ou "), simpleListPattern(", ", " e ")},
+	"nl": {simpleListPattern(", ", " en "), simpleListPattern(", ", " of "), simpleListPattern(", ", " en ")},
+	"sv": {simpleListPattern(", ", " och "), simpleListPattern(", ", " eller "), simpleListPattern(", ", " och ")},
+	"pl": {simpleListPattern(", ", " i "), simpleListPattern(", ", " lub "), simpleListPattern(", ", " i ")},
+	"ru": {simpleListPattern(", ", " и "), simpleListPattern(", ", " или "), simpleListPattern(" ", " ")},
+	"ar": {simpleListPattern(" و", " و"), simpleListPattern(" أو ", " أو "), simpleListPattern(" و", " و")},
+	"he": {simpleListPattern(", ", " ו"), simpleListPattern(", ", " או "), simpleListPattern(", ", " ו")},
+	"ja": {
+		simpleListPattern("、", "、"),
+		{start: "{0}、{1}", middle: "{0}、{1}", end: "{0}、または{1}", two: "{0}または{1}"},
+		simpleListPattern(" ", " "),
+	},
+	"zh": {simpleListPattern("、", "和"), simpleListPattern("、", "或"), simpleListPattern("", "")},
+	"ko": {simpleListPattern(", ", " 및 "), simpleListPattern(", ", " 또는 "), simpleListPattern(" ", " ")},
+}
+
+// formatList formats the items with the list patterns of the language.
+func formatList(tag language.Tag, style ListStyle, items []string) string {
+	patterns, ok := listPatterns[tag.String()]
+	if !ok {
+		base, _ := tag.Base()
+		if patterns, ok = listPatterns[base.String()]; !ok {
+			patterns = rootListPatterns
+		}
+	}
+	if style < ListConjunction || style > ListUnit {
+		style = ListConjunction
+	}
+	return patterns[style].format(items)
+}
+
+// List formats the items as a list in the language resolved by the translator,
+// for example, "Alice, Bob, and Carol" in English and "Alice、Bob和Carol" in Chinese.
+//
+// In the message templates, the list function formats a slice with the conjunction style,
+// or the style "and", "or" or "unit" given before the slice:
+//
+//	{{list .names}} {{list "or" .names}} {{.sizes | list "unit"}}
+func (i *I18n) List(style ListStyle, items ...string) string {
+	return formatList(i.matchedTag(), style, items)
+}
+
+// listFunc is the list template function, see [I18n.List].
+func (i *I18n) listFunc(args ...any) (string, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return "", exception.New(fmt.Sprintf("list: expected a style and a slice, got %d arguments", len(args)))
+	}
+	style := ListConjunction
+	if len(args) == 2 {
+		switch args[0] {
+		case "and", "conjunction":
+			style = ListConjunction
+		case "or", "disjunction":
+			style = ListDisjunction
+		case "unit":
+			style = ListUnit
+		default:
+			return "", exception.New(fmt.Sprintf("list: unknown style %v", args[0]))
+		}
+	}
+	items, err := listItems(args[len(args)-1])
+	if err != nil {
+		return "", err
+	}
+	return i.List(style, items...), nil
+}
+
+// listItems converts the slice to strings.
+func listItems(v any) ([]string, error) {
+	if items, ok := v.([]string); ok {
+		return items, nil
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, exception.New(fmt.Sprintf("list: expected a slice, got %T", v))
+	}
+	items := make([]string, value.Len())
+	for index := range items {
+		items[index] = fmt.Sprint(value.Index(index).Interface())
+	}
+	return items, nil
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_List(t *testing.T) {
+	t.Run("styles", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("es", Message(&i18n.Message{ID: "test", Other: "prueba"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("zh", Message(&i18n.Message{ID: "test", Other: "测试"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "", i.List(ListConjunction))
+			assert.Equal(t, "Alice", i.List(ListConjunction, "Alice"))
+			assert.Equal(t, "Alice and Bob", i.List(ListConjunction, "Alice", "Bob"))
+			assert.Equal(t, "Alice, Bob, and Carol", i.List(ListConjunction, "Alice", "Bob", "Carol"))
+			assert.Equal(t, "Alice, Bob, Carol, or Dave", i.List(ListDisjunction, "Alice", "Bob", "Carol", "Dave"))
+			assert.Equal(t, "5 feet, 2 inches", i.List(ListUnit, "5 feet", "2 inches"))
+			es := i.Locale("es-MX").(*I18n)
+			assert.Equal(t, "Alice, Bob y Carol", es.List(ListConjunction, "Alice", "Bob", "Carol"))
+			assert.Equal(t, "Alice o Bob", es.List(ListDisjunction, "Alice", "Bob"))
+			zh := i.Locale("zh").(*I18n)
+			assert.Equal(t, "Alice、Bob和Carol", zh.List(ListConjunction, "Alice", "Bob", "Carol"))
+			// ja is not a language of the bundle, the default language is resolved.
+			assert.Equal(t, "Alice and Bob", i.Locale("ja").(*I18n).List(ListConjunction, "Alice", "Bob"))
+		}
+	})
+
+	t.Run("template", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "invited", Other: "{{list .names}} are invited"}),
+				Message(&i18n.Message{ID: "pick", Other: "Pick {{list \"or\" .options}}"}),
+				Message(&i18n.Message{ID: "size", Other: "{{.parts | list \"unit\"}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("fr", Message(&i18n.Message{ID: "invited", Other: "{{list .names}} sont invités"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Alice, Bob, and Carol are invited", i.T("invited", "names", []string{"Alice", "Bob", "Carol"}))
+			assert.Equal(t, "Alice, Bob et Carol sont invités", i.Locale("fr").T("invited", "names", []string{"Alice", "Bob", "Carol"}))
+			assert.Equal(t, "Pick 1, 2, or 3", i.T("pick", "options", []int{1, 2, 3}))
+			assert.Equal(t, "5 feet, 2 inches", i.T("size", "parts", []any{"5 feet", "2 inches"}))
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Direction is the direction of the script of a language, it can be used as the dir attribute of HTML.
+type Direction string
+
+const (
+	// LeftToRight is the direction of the Latin, Cyrillic and most other scripts.
+	LeftToRight Direction = "ltr"
+	// RightToLeft is the direction of the Arabic, Hebrew and a few other scripts.
+	RightToLeft Direction = "rtl"
+)
+
+// rightToLeftScripts are the scripts written from right to left.
+var rightToLeftScripts = map[string]bool{
+	"Adlm": true,
+	"Arab": true,
+	"Hebr": true,
+	"Mand": true,
+	"Nkoo": true,
+	"Rohg": true,
+	"Samr": true,
+	"Syrc": true,
+	"Thaa": true,
+}
+
+// LanguageInfo is the metadata of a language of the translator, see [I18n.Languages].
+type LanguageInfo struct {
+	Tag language.Tag
+	// SelfName is the name of the language in the language itself, for example, "Deutsch".
+	SelfName string
+	// Name is the name of the language in the language resolved by the translator, for example, "German" in English.
+	Name string
+	// Direction is the direction of the script of the language.
+	Direction Direction
+	// PluralCategories are the CLDR cardinal plural categories of the language, for example, "one" and "other" in English.
+	PluralCategories []string
+	// OrdinalCategories are the CLDR ordinal plural categories of the language, see [I18n.PO].
+	OrdinalCategories []string
+}
+
+// Languages returns the metadata of the languages of [I18n.LanguageTags], for example, to build a language picker.
+// The names are empty if they are unknown, as well as for the pseudo-locales.
+func (i *I18n) Languages() []LanguageInfo {
+	namer := display.Tags(i.matchedTag())
+	var infos []LanguageInfo
+	for _, tag := range i.LanguageTags() {
+		infos = append(infos, LanguageInfo{
+			Tag:               tag,
+			SelfName:          display.Self.Name(tag),
+			Name:              namer.Name(tag),
+			Direction:         directionOf(tag),
+			PluralCategories:  PluralCategories(plural.Cardinal, tag),
+			OrdinalCategories: PluralCategories(plural.Ordinal, tag),
+		})
+	}
+	return infos
+}
+
+// directionOf returns the direction of the script of the language, the script is inferred if it is not given.
+func directionOf(tag language.Tag) Direction {
+	script, _ := tag.Script()
+	if rightToLeftScripts[script.String()] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
+
+// PluralCategories returns the plural categories used by the rules of the language in the CLDR order, such as
+// "one" and "other" for the English [plural.Cardinal] rules. The rules are matched against samples of integers and
+// decimals as they can not be listed.
+func PluralCategories(rules *plural.Rules, tag language.Tag) []string {
+	used := map[plural.Form]bool{}
+	for n := 0; n <= 1000; n++ {
+		used[rules.MatchPlural(tag, n, 0, 0, 0, 0)] = true
+	}
+	used[rules.MatchPlural(tag, 1000000, 0, 0, 0, 0)] = true
+	if rules == plural.Cardinal {
+		for n := 0; n <= 10; n++ {
+			used[rules.MatchPlural(tag, n, 1, 0, 0, 0)] = true
+			for f := 1; f <= 9; f++ {
+				used[rules.MatchPlural(tag, n, 1, 1, f, f)] = true
+			}
+		}
+	}
+	forms := []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+	var categories []string
+	for index, form := range forms {
+		if used[form] {
+			categories = append(categories, pluralKeys[index])
+		}
+	}
+	return categories
+}
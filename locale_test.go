@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+func TestI18n_Languages(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		for _, l := range []string{"de", "ar", "ru"} {
+			if err := i.AddMessages(l, Message(&i18n.Message{ID: "yes", Other: "yes"})); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		assert.Equal(t, []LanguageInfo{
+			{
+				Tag:               language.English,
+				SelfName:          "English",
+				Name:              "English",
+				Direction:         LeftToRight,
+				PluralCategories:  []string{"one", "other"},
+				OrdinalCategories: []string{"one", "two", "few", "other"},
+			},
+			{
+				Tag:               language.German,
+				SelfName:          "Deutsch",
+				Name:              "German",
+				Direction:         LeftToRight,
+				PluralCategories:  []string{"one", "other"},
+				OrdinalCategories: []string{"other"},
+			},
+			{
+				Tag:               language.Arabic,
+				SelfName:          "العربية",
+				Name:              "Arabic",
+				Direction:         RightToLeft,
+				PluralCategories:  []string{"zero", "one", "two", "few", "many", "other"},
+				OrdinalCategories: []string{"other"},
+			},
+			{
+				Tag:               language.Russian,
+				SelfName:          "русский",
+				Name:              "Russian",
+				Direction:         LeftToRight,
+				PluralCategories:  []string{"one", "few", "many", "other"},
+				OrdinalCategories: []string{"other"},
+			},
+		}, i.Languages())
+		languages := i.Locale("de").(*I18n).Languages()
+		assert.Equal(t, "Englisch", languages[0].Name)
+		assert.Equal(t, "Arabisch", languages[2].Name)
+		assert.Equal(t, RightToLeft, directionOf(language.MustParse("he")))
+		assert.Equal(t, LeftToRight, directionOf(language.MustParse("az-Latn")))
+	}
+}
+
+func TestPluralCategories(t *testing.T) {
+	assert.Equal(t, []string{"one", "other"}, PluralCategories(plural.Cardinal, language.English))
+	assert.Equal(t, []string{"one", "few", "many", "other"}, PluralCategories(plural.Cardinal, language.Russian))
+	assert.Equal(t, []string{"other"}, PluralCategories(plural.Cardinal, language.Japanese))
+	assert.Equal(t, []string{"one", "two", "few", "other"}, PluralCategories(plural.Ordinal, language.English))
+}
@@ -59,3 +59,19 @@ func (m *message) GetMany() string {
 func (m *message) GetOther() string {
 	return m.Other
 }
+
+func toI18nMessage(message translator.Message) *i18n.Message {
+	return &i18n.Message{
+		ID:          message.GetID(),
+		Hash:        message.GetHash(),
+		Description: message.GetDescription(),
+		LeftDelim:   message.GetLeftDelim(),
+		RightDelim:  message.GetRightDelim(),
+		Zero:        message.GetZero(),
+		One:         message.GetOne(),
+		Two:         message.GetTwo(),
+		Few:         message.GetFew(),
+		Many:        message.GetMany(),
+		Other:       message.GetOther(),
+	}
+}
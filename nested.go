@@ -0,0 +1,226 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+var pluralKeys = []string{"zero", "one", "two", "few", "many", "other"}
+
+var messageKeys = map[string]bool{
+	"id":          true,
+	"hash":        true,
+	"description": true,
+	"leftdelim":   true,
+	"rightdelim":  true,
+	"zero":        true,
+	"one":         true,
+	"two":         true,
+	"few":         true,
+	"many":        true,
+	"other":       true,
+}
+
+// NestedParser returns a parser which flattens nested objects to messages with dotted ids,
+// for example, {"auth": {"login": {"title": "Login"}}} is parsed to the message "auth.login.title".
+// Objects whose keys are all message fields and which contain at least one CLDR plural form
+// are parsed as a single message.
+func NestedParser(tag language.Tag, unmarshal func(data []byte, v any) error) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		var raw any
+		if err := unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		var messages []translator.Message
+		if err := flatten("", raw, &messages); err != nil {
+			return nil, err
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// NestedJSONParser returns a [NestedParser] for JSON.
+func NestedJSONParser(tag language.Tag) translator.Parser {
+	return NestedParser(tag, json.Unmarshal)
+}
+
+// NestedYAMLParser returns a [NestedParser] for YAML.
+func NestedYAMLParser(tag language.Tag) translator.Parser {
+	return NestedParser(tag, yaml.Unmarshal)
+}
+
+func flatten(id string, raw any, messages *[]translator.Message) error {
+	switch v := raw.(type) {
+	case map[string]any:
+		if id != "" && isNestedMessage(v) {
+			m := &i18n.Message{ID: id}
+			for key, value := range v {
+				setMessageField(m, key, fmt.Sprint(value))
+			}
+			*messages = append(*messages, Message(m))
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childID := key
+			if id != "" {
+				childID = id + "." + key
+			}
+			if err := flatten(childID, v[key], messages); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = value
+		}
+		return flatten(id, m, messages)
+	case []any:
+		return exception.New(fmt.Sprintf("unexpected list for message %q", id))
+	case nil:
+		return nil
+	default:
+		if id == "" {
+			return exception.New("invalid nested messages, expected an object")
+		}
+		*messages = append(*messages, Message(&i18n.Message{ID: id, Other: fmt.Sprint(v)}))
+		return nil
+	}
+}
+
+// isNestedMessage reports whether the object is a single message rather than a group of messages.
+func isNestedMessage(v map[string]any) bool {
+	hasPluralForm := false
+	for key, value := range v {
+		key = strings.ToLower(key)
+		if !messageKeys[key] {
+			return false
+		}
+		switch value.(type) {
+		case map[string]any, map[any]any, []any:
+			return false
+		}
+		for _, pluralKey := range pluralKeys {
+			if key == pluralKey {
+				hasPluralForm = true
+			}
+		}
+	}
+	return hasPluralForm
+}
+
+func setMessageField(m *i18n.Message, key, value string) {
+	switch strings.ToLower(key) {
+	case "hash":
+		m.Hash = value
+	case "description":
+		m.Description = value
+	case "leftdelim":
+		m.LeftDelim = value
+	case "rightdelim":
+		m.RightDelim = value
+	case "zero":
+		m.Zero = value
+	case "one":
+		m.One = value
+	case "two":
+		m.Two = value
+	case "few":
+		m.Few = value
+	case "many":
+		m.Many = value
+	case "other":
+		m.Other = value
+	}
+}
+
+// ExportNested encodes the message pack to nested objects, it is the inverse of [NestedParser].
+// Messages which only have the "other" form are encoded as strings.
+func ExportNested(messagePack translator.MessagePack, marshal func(v any) ([]byte, error)) ([]byte, error) {
+	root := make(map[string]any)
+	for _, message := range messagePack.GetMessages() {
+		if err := setNested(root, message.GetID(), nestedValue(message)); err != nil {
+			return nil, err
+		}
+	}
+	return marshal(root)
+}
+
+// setNested sets the value to the nested objects by the dotted id.
+func setNested(root map[string]any, id string, value any) error {
+	parts := strings.Split(id, ".")
+	node := root
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := node[part]
+		if !ok {
+			child = make(map[string]any)
+			node[part] = child
+		}
+		childNode, ok := child.(map[string]any)
+		if !ok {
+			return exception.New(fmt.Sprintf("message %q conflicts with message %q", id, strings.Join(parts[:len(parts)-1], ".")))
+		}
+		node = childNode
+	}
+	key := parts[len(parts)-1]
+	if _, ok := node[key]; ok {
+		return exception.New(fmt.Sprintf("message %q conflicts with other messages", id))
+	}
+	node[key] = value
+	return nil
+}
+
+// ExportNestedJSON encodes the message pack to nested JSON objects.
+func ExportNestedJSON(messagePack translator.MessagePack) ([]byte, error) {
+	return ExportNested(messagePack, func(v any) ([]byte, error) {
+		return json.MarshalIndent(v, "", "  ")
+	})
+}
+
+// ExportNestedYAML encodes the message pack to nested YAML objects.
+func ExportNestedYAML(messagePack translator.MessagePack) ([]byte, error) {
+	return ExportNested(messagePack, yaml.Marshal)
+}
+
+// nestedMessage is a message encoded as an object, it is distinguished from the groups of messages while exporting.
+type nestedMessage map[string]any
+
+func nestedValue(message translator.Message) any {
+	fields := map[string]string{
+		"hash":        message.GetHash(),
+		"description": message.GetDescription(),
+		"leftDelim":   message.GetLeftDelim(),
+		"rightDelim":  message.GetRightDelim(),
+		"zero":        message.GetZero(),
+		"one":         message.GetOne(),
+		"two":         message.GetTwo(),
+		"few":         message.GetFew(),
+		"many":        message.GetMany(),
+	}
+	v := nestedMessage{
+		"other": message.GetOther(),
+	}
+	for key, value := range fields {
+		if value != "" {
+			v[key] = value
+		}
+	}
+	if len(v) == 1 {
+		return message.GetOther()
+	}
+	return v
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestNestedParser(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`{
+					"auth": {
+						"login": {"title": "Login", "description": "Sign in"},
+						"attempts": {"description": "failed attempts", "one": "{{.PluralCount}} attempt", "other": "{{.PluralCount}} attempts"}
+					}
+				}`), nil
+			}), NestedJSONParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Login", i.T("auth.login.title"))
+			assert.Equal(t, "Sign in", i.T("auth.login.description"))
+			assert.Equal(t, "1 attempt", i.P("auth.attempts", 1))
+			assert.Equal(t, "3 attempts", i.P("auth.attempts", 3))
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		messagePack, err := NestedYAMLParser(language.English).Parse([]byte("auth:\n  login:\n    title: Login\n  items:\n    one: one item\n    other: many items\n"))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		messages := messagePack.GetMessages()
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, "auth.items", messages[0].GetID())
+			assert.Equal(t, "one item", messages[0].GetOne())
+			assert.Equal(t, "auth.login.title", messages[1].GetID())
+			assert.Equal(t, "Login", messages[1].GetOther())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NestedJSONParser(language.English).Parse([]byte(`{"auth": ["login"]}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestExportNested(t *testing.T) {
+	messagePack := MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "auth.login.title", Other: "Login"}),
+		Message(&i18n.Message{ID: "auth.attempts", Description: "failed attempts", One: "{{.PluralCount}} attempt", Other: "{{.PluralCount}} attempts"}),
+	}, language.English)
+
+	t.Run("json", func(t *testing.T) {
+		content, err := ExportNestedJSON(messagePack)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.JSONEq(t, `{"auth": {"login": {"title": "Login"}, "attempts": {"description": "failed attempts", "one": "{{.PluralCount}} attempt", "other": "{{.PluralCount}} attempts"}}}`, string(content))
+		parsed, err := NestedJSONParser(language.English).Parse(content)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Len(t, parsed.GetMessages(), 2)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		content, err := ExportNestedYAML(messagePack)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		parsed, err := NestedYAMLParser(language.English).Parse(content)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Len(t, parsed.GetMessages(), 2)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := ExportNestedJSON(MessagePack([]translator.Message{
+			Message(&i18n.Message{ID: "auth", Other: "Auth"}),
+			Message(&i18n.Message{ID: "auth.login", Other: "Login"}),
+		}, language.English))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// PO returns the translation for the given id and ordinal count, the plural form of the message is selected
+// by the CLDR ordinal rules of the resolved language instead of the cardinal ones, for example, the English
+// forms "one", "two", "few" and "other" are used for "1st", "2nd", "3rd" and "4th".
+// As with [I18n.P], the count is the "PluralCount" template data, it is added to the key-value pairs unless it is given.
+// If the length of data is even, it will be used as key-value pairs.
+// If the length of data is greater than 1 and is odd, it will be used as a slice.
+//
+//	i.AddMessages("en", i18n.Message(&i18nlib.Message{ID: "place", One: "{{.PluralCount}}st", Two: "{{.PluralCount}}nd", Few: "{{.PluralCount}}rd", Other: "{{.PluralCount}}th"}))
+//	i.PO("place", 22) // 22nd
+func (i *I18n) PO(id string, count any, data ...any) string {
+	id = i.prefix + id
+	lc := &i18n.LocalizeConfig{
+		MessageID:    id,
+		PluralCount:  count,
+		TemplateData: pluralTemplateData(data, count),
+	}
+	r, err := i.localizeOrdinal(lc)
+	if err != nil {
+		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+			return defaultMessage
+		}
+		return id
+	}
+	return r
+}
+
+// localizeOrdinal localizes the message the same way as [I18n.localize] with the ordinal plural form.
+func (i *I18n) localizeOrdinal(lc *i18n.LocalizeConfig) (string, error) {
+	n, err := ordinalOperand(lc.PluralCount)
+	if err != nil {
+		return "", err
+	}
+	return i.localizeForm(lc, func(tag language.Tag) plural.Form {
+		return plural.Ordinal.MatchPlural(tag, n, 0, 0, 0, 0)
+	})
+}
+
+// ordinalOperand returns the absolute value of the integer count.
+func ordinalOperand(count any) (int, error) {
+	n, err := strconv.Atoi(fmt.Sprint(count))
+	if err != nil {
+		return 0, exception.New(fmt.Sprintf("invalid ordinal count %v", count))
+	}
+	if n < 0 {
+		n = -n
+	}
+	return n, nil
+}
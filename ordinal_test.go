@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestI18n_PO(t *testing.T) {
+	t.Run("ordinal", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{
+				ID:    "place",
+				One:   "{{.PluralCount}}st place",
+				Two:   "{{.PluralCount}}nd place",
+				Few:   "{{.PluralCount}}rd place",
+				Other: "{{.PluralCount}}th place",
+			}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("fr", Message(&i18n.Message{
+				ID:    "place",
+				One:   "{{.PluralCount}}re place",
+				Other: "{{.PluralCount}}e place",
+			}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("cy", Message(&i18n.Message{
+				ID:    "place",
+				Zero:  "{{.PluralCount}}fed",
+				One:   "{{.PluralCount}}af",
+				Two:   "{{.PluralCount}}ail",
+				Few:   "{{.PluralCount}}ydd",
+				Many:  "{{.PluralCount}}ed",
+				Other: "{{.PluralCount}}fed",
+			}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			for count, expected := range map[any]string{1: "1st place", 2: "2nd place", 3: "3rd place", 4: "4th place", 11: "11th place", 22: "22nd place", "113": "113th place"} {
+				assert.Equal(t, expected, i.PO("place", count))
+			}
+			fr := i.Locale("fr").(*I18n)
+			assert.Equal(t, "1re place", fr.PO("place", 1))
+			assert.Equal(t, "2e place", fr.PO("place", 2))
+			cy := i.Locale("cy").(*I18n)
+			assert.Equal(t, "1af", cy.PO("place", 1))
+			assert.Equal(t, "2ail", cy.PO("place", 2))
+			assert.Equal(t, "3ydd", cy.PO("place", 3))
+			assert.Equal(t, "5ed", cy.PO("place", 5))
+			assert.Equal(t, "10fed", cy.PO("place", 10))
+			assert.Equal(t, "place", i.PO("place", "first"))
+			assert.Equal(t, "missing", i.PO("missing", 1))
+		}
+	})
+
+	t.Run("with data", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.Scope("race").AddMessages("en", Message(&i18n.Message{
+				ID:    "finished",
+				One:   "{{.name}} finished {{.place}}st",
+				Two:   "{{.name}} finished {{.place}}nd",
+				Few:   "{{.name}} finished {{.place}}rd",
+				Other: "{{.name}} finished {{.place}}th",
+			}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Alice finished 3rd", i.Scope("race").(*I18n).PO("finished", 3, "name", "Alice", "place", 3))
+		}
+	})
+
+	t.Run("lazy source", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddLazySource("fr", LoaderFunc(func() ([]byte, error) {
+				return []byte(`{"place": {"one": "{{.PluralCount}}er", "other": "{{.PluralCount}}e"}}`), nil
+			}), NestedJSONParser(language.French))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "1er", i.Locale("fr").(*I18n).PO("place", 1))
+			assert.Equal(t, "3e", i.Locale("fr").(*I18n).PO("place", 3))
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{
+				ID:    "place",
+				One:   "<b>{{.name}}</b> is {{.PluralCount}}st",
+				Other: "<b>{{.name}}</b> is {{.PluralCount}}th",
+			}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			h := *i
+			h.escapeHTML = true
+			assert.Equal(t, "<b>&lt;i&gt;Alice&lt;/i&gt;</b> is 1st", h.PO("place", 1, "name", "<i>Alice</i>"))
+			assert.Equal(t, "<b><i>Bob</i></b> is 4th", i.PO("place", 4, "name", "<i>Bob</i>"))
+		}
+	})
+}
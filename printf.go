@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var printfVerb = regexp.MustCompile(`%(?:(\d+)\$)?[-+#0']*\d*(?:\.\d+)?(?:hh|h|ll|l|q|L|z|t|j)?([@sdiufFeEgGxXoc%])`)
+
+// printfToTemplate converts the printf style placeholders to template fields,
+// the n-th argument (%s, %1$s, %d, %@, ...) is converted to {{.argn}}.
+func printfToTemplate(text string) string {
+	return replacePrintfArguments(text, func(index int, _ string) string {
+		return "{{.arg" + strconv.Itoa(index) + "}}"
+	})
+}
+
+// printfToPluralTemplate converts the printf style placeholders of a plural form to template fields,
+// the first integer argument (%d, %i, %u) is the quantity and is converted to {{.PluralCount}},
+// the other arguments are converted as [printfToTemplate] does.
+func printfToPluralTemplate(text string) string {
+	quantity := 0
+	replacePrintfArguments(text, func(index int, conversion string) string {
+		if quantity == 0 && strings.ContainsAny(conversion, "diu") {
+			quantity = index
+		}
+		return ""
+	})
+	return replacePrintfArguments(text, func(index int, _ string) string {
+		if index == quantity {
+			return "{{.PluralCount}}"
+		}
+		return "{{.arg" + strconv.Itoa(index) + "}}"
+	})
+}
+
+// replacePrintfArguments replaces the printf style placeholders with the field returned for their 1-based argument
+// and conversion, "%%" is replaced with "%".
+func replacePrintfArguments(text string, field func(index int, conversion string) string) string {
+	next := 0
+	return printfVerb.ReplaceAllStringFunc(text, func(verb string) string {
+		matches := printfVerb.FindStringSubmatch(verb)
+		if matches[2] == "%" {
+			return "%"
+		}
+		index := next + 1
+		if matches[1] != "" {
+			index, _ = strconv.Atoi(matches[1])
+		} else {
+			next++
+		}
+		return field(index, matches[2])
+	})
+}
+
+// templateToPrintf converts the template fields to positional printf placeholders, it is the inverse of [printfToTemplate].
+// {{.PluralCount}} uses the verb "d" and the other fields use the given verb, see [fieldPositions] for the positions.
+func templateToPrintf(text string, verb string) string {
+	position := fieldPositions(text)
+	text = strings.ReplaceAll(text, "%", "%%")
+	return templateField.ReplaceAllStringFunc(text, func(field string) string {
+		name := templateField.FindStringSubmatch(field)[1]
+		if name == "PluralCount" {
+			return "%" + strconv.Itoa(position(name)) + "$d"
+		}
+		return "%" + strconv.Itoa(position(name)) + "$" + verb
+	})
+}
+
+// fieldPositions returns the 1-based positional argument of each template field of the text.
+// {{.argn}} keeps its position, the other fields take the next free positions in order of appearance.
+func fieldPositions(text string) func(name string) int {
+	used := make(map[int]bool)
+	for _, matches := range templateField.FindAllStringSubmatch(text, -1) {
+		if index, ok := argIndex(matches[1]); ok {
+			used[index] = true
+		}
+	}
+	positions := make(map[string]int)
+	next := 1
+	return func(name string) int {
+		if index, ok := argIndex(name); ok {
+			return index
+		}
+		if index, ok := positions[name]; ok {
+			return index
+		}
+		for used[next] {
+			next++
+		}
+		used[next] = true
+		positions[name] = next
+		return next
+	}
+}
+
+func argIndex(name string) (int, bool) {
+	if !strings.HasPrefix(name, "arg") {
+		return 0, false
+	}
+	index, err := strconv.Atoi(name[3:])
+	return index, err == nil && index > 0
+}
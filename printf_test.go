@@ -0,0 +1,23 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintfToTemplate(t *testing.T) {
+	t.Run("placeholders", func(t *testing.T) {
+		assert.Equal(t, "{{.arg1}} and {{.arg2}}", printfToTemplate("%s and %@"))
+		assert.Equal(t, "{{.arg2}} before {{.arg1}}", printfToTemplate("%2$s before %1$d"))
+		assert.Equal(t, "{{.arg1}} items, {{.arg2}}", printfToTemplate("%-5d items, %.2f"))
+		assert.Equal(t, "100%", printfToTemplate("100%%"))
+	})
+
+	t.Run("literal percent", func(t *testing.T) {
+		assert.Equal(t, "50% off", printfToTemplate("50% off"))
+		assert.Equal(t, "100% sure", printfToTemplate("100% sure"))
+		assert.Equal(t, "5 % discount", printfToTemplate("5 % discount"))
+		assert.Equal(t, "50% off for {{.arg1}}", printfToTemplate("50% off for %s"))
+	})
+}
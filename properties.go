@@ -0,0 +1,232 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var messageFormatArg = regexp.MustCompile(`\{\s*(\d+)\s*(?:,[^{}]*)?\}`)
+
+// PropertiesParser returns a parser of Java .properties files.
+//
+// The comment lines before an entry are used as the description, MessageFormat arguments are converted to template
+// fields, the n-th argument ({0}, {1,number}, ...) is converted to {{.argn}} with n starting from 1, the same as the
+// placeholders of [AndroidParser].
+func PropertiesParser(tag language.Tag) translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		var messages []translator.Message
+		var comments []string
+		var logical strings.Builder
+		lineNo := 0
+		for scanner.Scan() {
+			lineNo++
+			line := strings.TrimLeft(scanner.Text(), " \t\f")
+			if logical.Len() == 0 {
+				if line == "" {
+					comments = nil
+					continue
+				}
+				if line[0] == '#' || line[0] == '!' {
+					comments = append(comments, strings.TrimSpace(line[1:]))
+					continue
+				}
+			}
+			if countTrailingBackslashes(line)%2 == 1 {
+				logical.WriteString(line[:len(line)-1])
+				continue
+			}
+			logical.WriteString(line)
+			key, value, err := splitProperty(logical.String())
+			if err != nil {
+				return nil, exception.New(fmt.Sprintf("line %d: %s", lineNo, err.Error()))
+			}
+			logical.Reset()
+			messages = append(messages, Message(&i18n.Message{
+				ID:          key,
+				Description: strings.Join(comments, "\n"),
+				Other:       messageFormatToTemplate(value),
+			}))
+			comments = nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return MessagePack(messages, tag), nil
+	})
+}
+
+// ExportProperties encodes the message pack to a Java .properties file, it is the inverse of [PropertiesParser].
+// Plural messages are exported with their "other" form only.
+func ExportProperties(messagePack translator.MessagePack) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, message := range messagePack.GetMessages() {
+		if description := message.GetDescription(); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				buf.WriteString("# " + line + "\n")
+			}
+		}
+		buf.WriteString(escapeProperty(message.GetID(), true) + "=" + escapeProperty(templateToMessageFormat(message.GetOther()), false) + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func countTrailingBackslashes(line string) int {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count
+}
+
+// splitProperty splits the logical line into the unescaped key and value.
+func splitProperty(line string) (string, string, error) {
+	var key strings.Builder
+	i := 0
+	for ; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) {
+			i++
+			key.WriteByte(line[i])
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			break
+		}
+		key.WriteByte(c)
+	}
+	rest := strings.TrimLeft(line[i:], " \t\f")
+	if strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":") {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	value, err := unescapeProperty(rest)
+	if err != nil {
+		return "", "", err
+	}
+	return key.String(), value, nil
+}
+
+func unescapeProperty(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", exception.New("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", exception.New("invalid unicode escape")
+			}
+			sb.WriteRune(rune(code))
+			i += 4
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+func escapeProperty(s string, key bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!', ' ':
+			if key || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// messageFormatToTemplate converts the MessageFormat arguments to template fields.
+// The quoting rules of MessageFormat are only applied to the texts having arguments.
+func messageFormatToTemplate(text string) string {
+	if !messageFormatArg.MatchString(text) {
+		return text
+	}
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\'':
+			if i+1 < len(text) && text[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i++
+				continue
+			}
+			end := strings.IndexByte(text[i+1:], '\'')
+			if end < 0 {
+				sb.WriteString(text[i+1:])
+				return sb.String()
+			}
+			sb.WriteString(text[i+1 : i+1+end])
+			i += end + 1
+		case '{':
+			loc := messageFormatArg.FindStringSubmatchIndex(text[i:])
+			if loc == nil || loc[0] != 0 {
+				sb.WriteByte(c)
+				continue
+			}
+			index, _ := strconv.Atoi(text[i+loc[2] : i+loc[3]])
+			sb.WriteString("{{.arg" + strconv.Itoa(index+1) + "}}")
+			i += loc[1] - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// templateToMessageFormat converts the template fields to MessageFormat arguments, it is the inverse of [messageFormatToTemplate].
+func templateToMessageFormat(text string) string {
+	if !templateField.MatchString(text) {
+		return text
+	}
+	position := fieldPositions(text)
+	quoter := strings.NewReplacer("'", "''", "{", "'{'", "}", "'}'")
+	var sb strings.Builder
+	last := 0
+	for _, loc := range templateField.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(quoter.Replace(text[last:loc[0]]))
+		sb.WriteString("{" + strconv.Itoa(position(text[loc[2]:loc[3]])-1) + "}")
+		last = loc[1]
+	}
+	sb.WriteString(quoter.Replace(text[last:]))
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestPropertiesParser(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+			return []byte(`# greeting on the home screen
+welcome = Welcome, {0}! It''s {1,number} o''clock.
+! plain text is not quoted
+plain: It's \
+    done
+escaped\ key=café
+`), nil
+		}), PropertiesParser(language.English))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "Welcome, world! It's 5 o'clock.", i.T("welcome", map[string]any{"arg1": "world", "arg2": 5}))
+		assert.Equal(t, "It's done", i.T("plain"))
+		assert.Equal(t, "café", i.T("escaped key"))
+	}
+}
+
+func TestExportProperties(t *testing.T) {
+	content, err := ExportProperties(MessagePack([]translator.Message{
+		Message(&i18n.Message{ID: "welcome", Description: "greeting", Other: "Welcome, {{.name}}! It's {{.arg1}}"}),
+		Message(&i18n.Message{ID: "plain", Other: "It's done"}),
+	}, language.English))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "# greeting\nwelcome=Welcome, {1}! It''s {0}\nplain=It's done\n", string(content))
+	parsed, err := PropertiesParser(language.English).Parse(content)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if assert.Len(t, parsed.GetMessages(), 2) {
+		assert.Equal(t, "greeting", parsed.GetMessages()[0].GetDescription())
+		assert.Equal(t, "Welcome, {{.arg2}}! It's {{.arg1}}", parsed.GetMessages()[0].GetOther())
+		assert.Equal(t, "It's done", parsed.GetMessages()[1].GetOther())
+	}
+}
@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"golang.org/x/text/language"
+)
+
+// PseudoLocale describes how a pseudo-locale transforms the messages of the default language,
+// see [I18n.AddPseudoLocale].
+type PseudoLocale struct {
+	// Transform transforms the literal text between the template actions.
+	Transform func(text string) string
+	// Expansion is the length of the padding added to the message, relative to the length of its literal text.
+	Expansion float64
+	// Prefix and Suffix wrap the message.
+	Prefix string
+	Suffix string
+}
+
+var (
+	// PseudoAccented is the en-XA pseudo-locale, the letters are accented,
+	// the messages are expanded by 35% and wrapped in brackets.
+	PseudoAccented = PseudoLocale{Transform: accentText, Expansion: 0.35, Prefix: "[", Suffix: "]"}
+	// PseudoBidi is the ar-XB pseudo-locale, each word is wrapped in right-to-left override marks
+	// so the text is displayed mirrored.
+	PseudoBidi = PseudoLocale{Transform: mirrorText}
+)
+
+var accents = map[rune]rune{
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Đ', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ',
+	'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ',
+	'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ',
+	'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ',
+	'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+}
+
+// pseudoPadding is repeated to pad the expanded messages.
+const pseudoPadding = " one two three four five six seven eight nine ten"
+
+func accentText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if accented, ok := accents[r]; ok {
+			return accented
+		}
+		return r
+	}, text)
+}
+
+func mirrorText(text string) string {
+	var sb strings.Builder
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			sb.WriteString("\u200f\u202e" + text[start:end] + "\u202c\u200f")
+			start = -1
+		}
+	}
+	for index, r := range text {
+		if r == ' ' || r == '\t' || r == '\n' {
+			flush(index)
+			sb.WriteRune(r)
+		} else if start < 0 {
+			start = index
+		}
+	}
+	flush(len(text))
+	return sb.String()
+}
+
+// transform transforms the template source, the template actions are kept intact,
+// as well as the tags if the source is markup.
+func (p PseudoLocale) transform(src, leftDelim, rightDelim string, markup bool) string {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	var sb strings.Builder
+	literal := 0
+	from := 0
+	flush := func(to int) {
+		literal += utf8.RuneCountInString(src[from:to])
+		if p.Transform != nil {
+			sb.WriteString(p.Transform(src[from:to]))
+		} else {
+			sb.WriteString(src[from:to])
+		}
+	}
+	for k := 0; k < len(src); {
+		end := -1
+		if strings.HasPrefix(src[k:], leftDelim) {
+			end = actionEnd(src, k, leftDelim, rightDelim)
+		} else if markup && src[k] == '<' {
+			if tagEnd, ok := tagEnd(src, k, leftDelim, rightDelim); ok {
+				end = tagEnd
+			}
+		}
+		if end < 0 {
+			k++
+			continue
+		}
+		flush(k)
+		sb.WriteString(src[k:end])
+		from, k = end, end
+	}
+	flush(len(src))
+	if padding := int(math.Ceil(float64(literal) * p.Expansion)); padding > 0 {
+		sb.WriteString(strings.Repeat(pseudoPadding, padding/len(pseudoPadding)+1)[:padding])
+	}
+	return p.Prefix + sb.String() + p.Suffix
+}
+
+// pseudoParser transforms the template source before it is parsed by the wrapped parser.
+type pseudoParser struct {
+	parser template.Parser
+	pseudo PseudoLocale
+}
+
+func (p *pseudoParser) Cacheable() bool {
+	// the parsed templates are cached by the messages, which are shared with the default language.
+	return false
+}
+
+func (p *pseudoParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	markup := false
+	switch p.parser.(type) {
+	case *htmlParser, *richParser:
+		markup = true
+	}
+	return p.parser.Parse(p.pseudo.transform(src, leftDelim, rightDelim, markup), leftDelim, rightDelim)
+}
+
+type pseudoRegistry struct {
+	mu      sync.RWMutex
+	locales map[language.Tag]PseudoLocale
+	tags    []language.Tag
+}
+
+func newPseudoRegistry() *pseudoRegistry {
+	return &pseudoRegistry{locales: make(map[language.Tag]PseudoLocale)}
+}
+
+func (r *pseudoRegistry) languageTags() []language.Tag {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]language.Tag{}, r.tags...)
+}
+
+// match returns the pseudo-locale of the first wanted language which is registered as a pseudo-locale, the wanted
+// languages before it must not match any of the given languages. The pseudo-locales are only selected by their exact
+// tags, so the wanted languages such as "en-GB" are never resolved to "en-XA".
+func (r *pseudoRegistry) match(tags []language.Tag, want []language.Tag) (PseudoLocale, language.Tag, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.locales) == 0 {
+		return PseudoLocale{}, language.Und, false
+	}
+	matcher := language.NewMatcher(tags)
+	for _, tag := range want {
+		if pseudo, ok := r.locales[tag]; ok {
+			return pseudo, tag, true
+		}
+		if _, _, confidence := matcher.Match(tag); confidence != language.No {
+			break
+		}
+	}
+	return PseudoLocale{}, language.Und, false
+}
+
+// AddPseudoLocale registers a pseudo-locale, which renders the messages of the default language transformed
+// by the given [PseudoLocale] when it is resolved by [I18n.Locale], the template actions are kept intact.
+//
+//	i.AddPseudoLocale("en-XA", i18n.PseudoAccented)
+//	i.AddPseudoLocale("ar-XB", i18n.PseudoBidi)
+//	i.Locale("en-XA").T("welcome") // [Ŵéļçöɱé on]
+func (i *I18n) AddPseudoLocale(l string, pseudo PseudoLocale) error {
+	languageTag, err := language.Parse(l)
+	if err != nil {
+		return err
+	}
+	i.AddPseudoLocaleByLanguageTag(languageTag, pseudo)
+	return nil
+}
+
+// AddPseudoLocaleByLanguageTag registers a pseudo-locale by language tag, see [I18n.AddPseudoLocale].
+func (i *I18n) AddPseudoLocaleByLanguageTag(languageTag language.Tag, pseudo PseudoLocale) {
+	i.pseudo.mu.Lock()
+	defer i.pseudo.mu.Unlock()
+	if _, ok := i.pseudo.locales[languageTag]; !ok {
+		i.pseudo.tags = append(i.pseudo.tags, languageTag)
+	}
+	i.pseudo.locales[languageTag] = pseudo
+	i.languagesChanged()
+}
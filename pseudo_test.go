@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestI18n_AddPseudoLocale(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.AddMessages("en",
+			Message(&i18n.Message{ID: "welcome", Other: "Welcome, {{.name}}!"}),
+			Message(&i18n.Message{ID: "items", One: "{{.PluralCount}} item", Other: "{{.PluralCount}} items"}),
+			Message(&i18n.Message{ID: "delims", LeftDelim: "<<", RightDelim: ">>", Other: "Hi <<.name>>"}),
+		)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if err := i.AddMessages("fr", Message(&i18n.Message{ID: "welcome", Other: "Bienvenue, {{.name}} !"})); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		if err := i.AddPseudoLocale("en-XA", PseudoAccented); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		i.AddPseudoLocaleByLanguageTag(language.MustParse("ar-XB"), PseudoBidi)
+
+		t.Run("accented", func(t *testing.T) {
+			assert.Equal(t, "[Ŵéļçöɱé, world! one]", i.Locale("en-XA").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "[1 îţéɱ o]", i.Locale("en-XA").P("items", 1))
+			assert.Equal(t, "[2 îţéɱš on]", i.Locale("en-XA").P("items", 2))
+			assert.Equal(t, "[Ĥî Bob o]", i.Locale("en-XA").T("delims", map[string]any{"name": "Bob"}))
+		})
+
+		t.Run("bidi", func(t *testing.T) {
+			assert.Equal(t, "‏‮Welcome,‬‏ world‏‮!‬‏", i.Locale("ar-XB").T("welcome", map[string]any{"name": "world"}))
+		})
+
+		t.Run("other locales", func(t *testing.T) {
+			assert.Equal(t, "Welcome, world!", i.T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "Welcome, world!", i.Locale("en-US").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "Bienvenue, world !", i.Locale("fr").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "Welcome, world!", i.Locale("en-GB").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "Welcome, world!", i.Locale("ar").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "Welcome, world!", i.Locale("ar-EG").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "2 items", i.Locale("en-GB").P("items", 2))
+			assert.Equal(t, LeftToRight, i.Locale("ar").(*I18n).Direction())
+			assert.Equal(t, "Bienvenue, world !", i.Locale("fr", "en-XA").T("welcome", map[string]any{"name": "world"}))
+			assert.Equal(t, "[Ŵéļçöɱé, world! one]", i.Locale("ja", "en-XA").T("welcome", map[string]any{"name": "world"}))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package i18n
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+)
+
+// RichNode is a node of a rich-text message parsed by [I18n.RichTree],
+// a text node has no tag, a tag node has the tag name and its children.
+type RichNode struct {
+	Tag      string
+	Text     string
+	Children []RichNode
+}
+
+// the tag placeholders of the template source are replaced by the markers followed by a random nonce before the
+// template is executed, so the tags given by the template data are kept as text and the markers given by the template
+// data are removed.
+const (
+	richOpen  = '\uE000'
+	richClose = '\uE001'
+	richEmpty = '\uE002'
+	richEnd   = '\uE003'
+)
+
+var richTag = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9_.-]*)\s*(/?)>`)
+
+// Rich returns the translation for the given id with its tag placeholders rendered by the renderers,
+// for example, "Read the <link>terms</link>" is rendered with the renderer of "link" given the rendered "terms".
+// Self-closing tags such as "<br/>" are rendered given an empty string, the tags without a renderer are rendered
+// as their children. The tags are only recognized in the translation, the tags in the template data are kept as text.
+// If the length of data is even, it will be used as key-value pairs.
+// If the length of data is greater than 1 and is odd, it will be used as a slice.
+//
+//	// "Read the <link>terms</link> and <b>{{.name}}</b>"
+//	i.Rich("agreement", map[string]func(string) string{
+//		"link": func(children string) string { return `<a href="/terms">` + children + "</a>" },
+//		"b":    func(children string) string { return "**" + children + "**" },
+//	}, "name", "Alice")
+func (i *I18n) Rich(id string, renderers map[string]func(children string) string, data ...any) string {
+	return renderRich(i.RichTree(id, data...), renderers)
+}
+
+// RichTree returns the translation for the given id parsed as a tree of text and tag nodes, see [I18n.Rich].
+func (i *I18n) RichTree(id string, data ...any) []RichNode {
+	id = i.prefix + id
+	lc := &i18n.LocalizeConfig{
+		MessageID:    id,
+		TemplateData: templateData(data),
+		Funcs:        i.templateFuncs(id),
+	}
+	parser := &richParser{parser: i.textParser(lc.Funcs), nonce: richNonce()}
+	lc.TemplateParser = parser
+	r, err := i.localize(lc)
+	if err != nil {
+		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+			return []RichNode{{Text: defaultMessage}}
+		}
+		return []RichNode{{Text: id}}
+	}
+	return parseRich(r, parser.nonce)
+}
+
+// richNonce returns a random nonce of the markers.
+func richNonce() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// richParser replaces the tag placeholders of the template source by markers before it is parsed by the wrapped parser.
+type richParser struct {
+	parser template.Parser
+	nonce  string
+}
+
+func (p *richParser) Cacheable() bool {
+	return false
+}
+
+func (p *richParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	var sb strings.Builder
+	for k := 0; k < len(src); {
+		if strings.HasPrefix(src[k:], leftDelim) {
+			end := actionEnd(src, k, leftDelim, rightDelim)
+			sb.WriteString(src[k:end])
+			k = end
+			continue
+		}
+		if matches := richTag.FindStringSubmatch(src[k:]); matches != nil && (matches[1] == "" || matches[3] == "") {
+			switch {
+			case matches[1] == "/":
+				sb.WriteRune(richClose)
+			case matches[3] == "/":
+				sb.WriteRune(richEmpty)
+			default:
+				sb.WriteRune(richOpen)
+			}
+			sb.WriteString(p.nonce)
+			sb.WriteString(matches[2])
+			sb.WriteRune(richEnd)
+			k += len(matches[0])
+			continue
+		}
+		sb.WriteByte(src[k])
+		k++
+	}
+	return p.parser.Parse(sb.String(), leftDelim, rightDelim)
+}
+
+// parseRich parses the markers with the nonce of the rendered message, the unmatched closing tags are kept as text
+// and the unclosed tags are closed at the end. The markers without the nonce are removed.
+func parseRich(s, nonce string) []RichNode {
+	type element struct {
+		tag      string
+		children []RichNode
+	}
+	stack := []*element{{}}
+	appendNode := func(node RichNode) {
+		if node.Tag == "" {
+			node.Text = strings.Map(func(r rune) rune {
+				if r >= richOpen && r <= richEnd {
+					return -1
+				}
+				return r
+			}, node.Text)
+			if node.Text == "" {
+				return
+			}
+		}
+		top := stack[len(stack)-1]
+		if node.Tag == "" && len(top.children) > 0 && top.children[len(top.children)-1].Tag == "" {
+			top.children[len(top.children)-1].Text += node.Text
+			return
+		}
+		top.children = append(top.children, node)
+	}
+	for s != "" {
+		start := strings.IndexAny(s, string([]rune{richOpen, richClose, richEmpty}))
+		if start < 0 {
+			appendNode(RichNode{Text: s})
+			break
+		}
+		if start > 0 {
+			appendNode(RichNode{Text: s[:start]})
+		}
+		marker, size := utf8.DecodeRuneInString(s[start:])
+		if !strings.HasPrefix(s[start+size:], nonce) {
+			s = s[start+size:]
+			continue
+		}
+		end := strings.IndexRune(s[start:], richEnd)
+		if end < 0 {
+			appendNode(RichNode{Text: s[start:]})
+			break
+		}
+		tag := s[start+size+len(nonce) : start+end]
+		s = s[start+end+utf8.RuneLen(richEnd):]
+		switch marker {
+		case richOpen:
+			stack = append(stack, &element{tag: tag})
+		case richEmpty:
+			appendNode(RichNode{Tag: tag})
+		case richClose:
+			index := len(stack) - 1
+			for index > 0 && stack[index].tag != tag {
+				index--
+			}
+			if index == 0 {
+				appendNode(RichNode{Text: "</" + tag + ">"})
+				continue
+			}
+			for len(stack) > index {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				appendNode(RichNode{Tag: top.tag, Children: top.children})
+			}
+		}
+	}
+	for len(stack) > 1 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		appendNode(RichNode{Tag: top.tag, Children: top.children})
+	}
+	return stack[0].children
+}
+
+// renderRich renders the nodes with the renderers of their tags.
+func renderRich(nodes []RichNode, renderers map[string]func(children string) string) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		if node.Tag == "" {
+			sb.WriteString(node.Text)
+			continue
+		}
+		children := renderRich(node.Children, renderers)
+		if render, ok := renderers[node.Tag]; ok {
+			sb.WriteString(render(children))
+		} else {
+			sb.WriteString(children)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Rich(t *testing.T) {
+	renderers := map[string]func(string) string{
+		"link": func(children string) string { return `<a href="/terms">` + children + "</a>" },
+		"b":    func(children string) string { return "**" + children + "**" },
+		"br":   func(string) string { return "\n" },
+	}
+
+	t.Run("render", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "agreement", Other: "Read the <link>terms</link> and <b>{{.name}}</b>"}),
+				Message(&i18n.Message{ID: "nested", Other: "<link>the <b>terms</b></link><br/>done"}),
+				Message(&i18n.Message{ID: "unknown", Other: "<i>terms</i>"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("fr", Message(&i18n.Message{ID: "agreement", Other: "<b>{{.name}}</b>, lisez les <link>conditions</link>"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, `Read the <a href="/terms">terms</a> and **Alice**`, i.Rich("agreement", renderers, "name", "Alice"))
+			assert.Equal(t, `**Alice**, lisez les <a href="/terms">conditions</a>`, i.Locale("fr").(*I18n).Rich("agreement", renderers, "name", "Alice"))
+			assert.Equal(t, "<a href=\"/terms\">the **terms**</a>\ndone", i.Rich("nested", renderers))
+			assert.Equal(t, "terms", i.Rich("unknown", renderers))
+			assert.Equal(t, "Read the <link> and **<b>x</b>**", i.Rich("agreement", map[string]func(string) string{
+				"link": func(string) string { return "<link>" },
+				"b":    renderers["b"],
+			}, "name", "<b>x</b>"))
+			assert.Equal(t, "missing", i.Rich("missing", renderers))
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "agreement", Other: "Read the <link>terms</link> and <b>{{.name}}</b>"}),
+				Message(&i18n.Message{ID: "unbalanced", Other: "a </b> <link>b <b>c</link> <i>d"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, []RichNode{
+				{Text: "Read the "},
+				{Tag: "link", Children: []RichNode{{Text: "terms"}}},
+				{Text: " and "},
+				{Tag: "b", Children: []RichNode{{Text: "<i>Alice</i>"}}},
+			}, i.RichTree("agreement", "name", "<i>Alice</i>"))
+			assert.Equal(t, []RichNode{
+				{Text: "a </b> "},
+				{Tag: "link", Children: []RichNode{{Text: "b "}, {Tag: "b", Children: []RichNode{{Text: "c"}}}}},
+				{Text: " "},
+				{Tag: "i", Children: []RichNode{{Text: "d"}}},
+			}, i.RichTree("unbalanced"))
+			assert.Equal(t, []RichNode{
+				{Text: "Read the "},
+				{Tag: "link", Children: []RichNode{{Text: "terms"}}},
+				{Text: " and "},
+				{Tag: "b", Children: []RichNode{{Text: "<link>linkxlinky"}}},
+			}, i.RichTree("agreement", "name", "<link>\uE000link\uE003x\uE001link\uE003y"))
+		}
+	})
+
+	t.Run("pseudo-locale", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{ID: "agreement", Other: "Read the <link>terms</link>"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			if err := i.AddPseudoLocale("en-XA", PseudoLocale{Transform: strings.ToUpper}); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, `READ THE <a href="/terms">TERMS</a>`, i.Locale("en-XA").(*I18n).Rich("agreement", renderers))
+		}
+	})
+}
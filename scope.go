@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Scope returns a translator which prefixes the message ids with the given namespace,
+// for example, T("title") of Scope("billing.invoice") translates "billing.invoice.title".
+// The messages added or loaded by the returned translator are registered under the namespace too.
+// The translator itself is returned if the namespace is empty.
+func (i *I18n) Scope(namespace string) translator.Translator {
+	namespace = strings.TrimSuffix(namespace, ".")
+	if namespace == "" {
+		return i
+	}
+	s := *i
+	s.prefix = i.prefix + namespace + "."
+	return &s
+}
+
+// LoadNamespacedMessageFile loads messages from a file and registers them under the namespace of the file.
+// The file name should be ended with `{namespace}.{locale}.{format}`, for example,
+// the messages of "billing.fr.json" are registered under "billing.".
+func (i *I18n) LoadNamespacedMessageFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return i.loadMessageFileBytes(content, path, namespacePrefix(path))
+}
+
+// LoadNamespacedMessageFileFS loads messages from a file from the given file system
+// and registers them under the namespace of the file.
+func (i *I18n) LoadNamespacedMessageFileFS(fsys fs.FS, path string) error {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+	return i.loadMessageFileBytes(content, path, namespacePrefix(path))
+}
+
+// loadMessageFileBytes parses the messages of a file and adds them with the given prefix,
+// the messages are registered under the namespace of the translator too.
+func (i *I18n) loadMessageFileBytes(content []byte, path, prefix string) error {
+	messageFile, err := i18n.ParseMessageFileBytes(content, path, i.unmarshalFuncs)
+	if err != nil {
+		return err
+	}
+	var messages []translator.Message
+	for _, m := range messageFile.Messages {
+		m.ID = prefix + m.ID
+		messages = append(messages, Message(m))
+	}
+	return i.AddMessagesByLanguageTag(messageFile.Tag, messages...)
+}
+
+// namespacePrefix returns the prefix of the namespace of the file, see [namespaceOf].
+func namespacePrefix(path string) string {
+	if namespace := namespaceOf(path); namespace != "" {
+		return namespace + "."
+	}
+	return ""
+}
+
+// namespaceOf returns the part of the file name before .{locale}.{format}.
+func namespaceOf(path string) string {
+	parts := strings.Split(filepath.Base(path), ".")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], ".")
+}
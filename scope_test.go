@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Scope(t *testing.T) {
+	t.Run("translate", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en", Message(&i18n.Message{ID: "billing.invoice.title", Other: "Invoice"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("zh", Message(&i18n.Message{ID: "billing.invoice.title", Other: "发票"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			scoped := i.Scope("billing.invoice")
+			assert.Equal(t, "Invoice", scoped.T("title"))
+			assert.Equal(t, "发票", scoped.Locale("zh").T("title"))
+			assert.Equal(t, "Invoice", i.Scope("billing").(*I18n).Scope("invoice").T("title"))
+			assert.Equal(t, "billing.invoice.missing", scoped.T("missing"))
+		}
+	})
+
+	t.Run("add messages", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.Scope("billing").AddMessages("en", Message(&i18n.Message{ID: "title", Other: "Billing"}))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Billing", i.T("billing.title"))
+			assert.Equal(t, "title", i.T("title"))
+		}
+	})
+
+	t.Run("load message file", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			assert.NoError(t, i.Scope("app").(*I18n).LoadMessageFile("testdata/billing.en.json"))
+			assert.NoError(t, i.Scope("fs").(*I18n).LoadMessageFileFS(os.DirFS("testdata"), "billing.en.json"))
+			assert.Equal(t, "Invoice", i.T("app.invoice.title"))
+			assert.Equal(t, "Invoice", i.T("fs.invoice.title"))
+			assert.Equal(t, "invoice.title", i.T("invoice.title"))
+		}
+	})
+
+	t.Run("empty namespace", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			assert.Same(t, i, i.Scope(""))
+			assert.Same(t, i, i.Scope("."))
+			scoped := i.Scope("billing").(*I18n)
+			assert.Same(t, scoped, scoped.Scope(""))
+		}
+	})
+}
+
+func TestI18n_LoadNamespacedMessageFile(t *testing.T) {
+	t.Run("load from file", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadNamespacedMessageFile("testdata/billing.en.json")
+			assert.NoError(t, err)
+			assert.Equal(t, "Invoice", i.T("billing.invoice.title"))
+			assert.Equal(t, "2 items", i.Scope("billing.invoice").P("total", 2))
+		}
+	})
+
+	t.Run("load from file system", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.Scope("app").(*I18n).LoadNamespacedMessageFileFS(os.DirFS("testdata"), "billing.en.json")
+			assert.NoError(t, err)
+			assert.Equal(t, "Invoice", i.T("app.billing.invoice.title"))
+		}
+	})
+
+	t.Run("namespace of file name", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadNamespacedMessageFileFS(os.DirFS("testdata"), "test.en.json")
+			assert.NoError(t, err)
+			assert.Equal(t, "test one", i.T("test.test"))
+		}
+	})
+}
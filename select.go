@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// SelectSeparator separates the message id and the selector in the ids of the select variants,
+// the variant "female" of the message "greeting" is the message "greeting_female".
+// It is the context separator of i18next, so the variants of i18next resources are loaded as is.
+const SelectSeparator = "_"
+
+// S returns the translation of the variant of the message selected by the given selector,
+// for example, the grammatical gender, the formality or the role of the user.
+//
+// The variant is the message "{id}_{selector}", the message id itself is the "other" variant,
+// which is used when the selector is empty or the resolved language has no such variant.
+// If the length of data is even, it will be used as key-value pairs.
+// If the length of data is greater than 1 and is odd, it will be used as a slice.
+//
+//	i.S("greeting", "female", "name", "Alice") // translates "greeting_female" or "greeting"
+func (i *I18n) S(id string, selector any, data ...any) string {
+	return i.selectVariant(id, selector, nil, data)
+}
+
+// SP returns the translation of the variant of the message selected by the given selector and plural count,
+// see [I18n.S] and [I18n.P].
+func (i *I18n) SP(id string, selector any, pluralCount any, data ...any) string {
+	return i.selectVariant(id, selector, pluralCount, data)
+}
+
+func (i *I18n) selectVariant(id string, selector, pluralCount any, data []any) string {
+	id = i.prefix + id
+	lc := &i18n.LocalizeConfig{
+		PluralCount:  pluralCount,
+		TemplateData: pluralTemplateData(data, pluralCount),
+	}
+	var variant string
+	if selector != nil {
+		if s := fmt.Sprint(selector); s != "" {
+			variant = id + SelectSeparator + s
+		}
+	}
+	if variant != "" {
+		lc.MessageID = variant
+		if r, err := i.localize(lc); err == nil {
+			return r
+		}
+	}
+	lc.MessageID = id
+	r, err := i.localize(lc)
+	if err != nil {
+		if variant != "" {
+			if defaultMessage := GetDefaultMessage(variant); defaultMessage != "" {
+				return defaultMessage
+			}
+		}
+		if defaultMessage := GetDefaultMessage(id); defaultMessage != "" {
+			return defaultMessage
+		}
+		return id
+	}
+	return r
+}
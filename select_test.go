@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestI18n_S(t *testing.T) {
+	t.Run("select", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "greeting", Other: "Welcome, {{.name}}"}),
+				Message(&i18n.Message{ID: "greeting_female", Other: "Welcome, Ms. {{.name}}"}),
+				Message(&i18n.Message{ID: "greeting_male", Other: "Welcome, Mr. {{.name}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			err = i.AddMessages("de",
+				Message(&i18n.Message{ID: "greeting", Other: "Willkommen, {{.name}}"}),
+				Message(&i18n.Message{ID: "greeting_female", Other: "Willkommen, Frau {{.name}}"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Welcome, Ms. Alice", i.S("greeting", "female", "name", "Alice"))
+			assert.Equal(t, "Welcome, Mr. Bob", i.S("greeting", "male", "name", "Bob"))
+			assert.Equal(t, "Welcome, Sam", i.S("greeting", "unknown", "name", "Sam"))
+			assert.Equal(t, "Welcome, Sam", i.S("greeting", nil, "name", "Sam"))
+			de := i.Locale("de").(*I18n)
+			assert.Equal(t, "Willkommen, Frau Alice", de.S("greeting", "female", "name", "Alice"))
+			assert.Equal(t, "Willkommen, Bob", de.S("greeting", "male", "name", "Bob"))
+			assert.Equal(t, "missing", i.S("missing", "female"))
+		}
+	})
+
+	t.Run("select with plural count", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "invite", One: "{{.name}} invited you", Other: "{{.name}} invited you and {{.PluralCount}} others"}),
+				Message(&i18n.Message{ID: "invite_female", One: "{{.name}} invited you to her party", Other: "{{.name}} invited you and {{.PluralCount}} others to her party"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Alice invited you to her party", i.SP("invite", "female", 1, "name", "Alice", "PluralCount", 1))
+			assert.Equal(t, "Alice invited you and 3 others to her party", i.SP("invite", "female", 3, "name", "Alice", "PluralCount", 3))
+			assert.Equal(t, "Sam invited you and 2 others", i.SP("invite", "other", 2, "name", "Sam", "PluralCount", 2))
+		}
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.AddMessages("en",
+				Message(&i18n.Message{ID: "profile.title", Other: "Profile"}),
+				Message(&i18n.Message{ID: "profile.title_admin", Other: "Administrator profile"}),
+			)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "Administrator profile", i.Scope("profile").(*I18n).S("title", "admin"))
+			assert.Equal(t, "Profile", i.Scope("profile").(*I18n).S("title", "guest"))
+		}
+	})
+
+	t.Run("i18next context", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return []byte(`{
+					"friend_one": "A friend",
+					"friend_other": "{{count}} friends",
+					"friend_male_one": "A boyfriend",
+					"friend_male_other": "{{count}} boyfriends"
+				}`), nil
+			}), I18nextParser(language.English))
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, "A boyfriend", i.SP("friend", "male", 1, "count", 1))
+			assert.Equal(t, "2 boyfriends", i.SP("friend", "male", 2, "count", 2))
+			assert.Equal(t, "2 friends", i.SP("friend", "female", 2, "count", 2))
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/gopi-frame/exception"
+)
+
+// ErrInvalidSignature is returned when a signed message pack can not be verified by any of the configured public keys.
+var ErrInvalidSignature = exception.New("invalid message pack signature")
+
+// Envelope is a signed message pack payload.
+//
+// It is encoded as JSON, payload and signature are base64 encoded, for example:
+//
+//	{"payload": "W3siaWQiOiAidGVzdCJ9XQ==", "signature": "..."}
+type Envelope struct {
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// Sign signs the payload with the given private key and returns the encoded [Envelope].
+func Sign(privateKey ed25519.PrivateKey, payload []byte) ([]byte, error) {
+	return json.Marshal(&Envelope{
+		Payload:   payload,
+		Signature: SignDetached(privateKey, payload),
+	})
+}
+
+// SignDetached signs the payload with the given private key and returns the detached signature.
+func SignDetached(privateKey ed25519.PrivateKey, payload []byte) []byte {
+	return ed25519.Sign(privateKey, payload)
+}
+
+// Verify reports whether the signature of the payload is valid for any of the given public keys.
+func Verify(payload, signature []byte, publicKeys ...ed25519.PublicKey) error {
+	for _, publicKey := range publicKeys {
+		if len(publicKey) == ed25519.PublicKeySize && ed25519.Verify(publicKey, payload, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// Open decodes the given [Envelope], verifies it with the given public keys and returns its payload.
+func Open(data []byte, publicKeys ...ed25519.PublicKey) ([]byte, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, exception.WithMessage(err, "invalid message pack envelope")
+	}
+	if err := Verify(envelope.Payload, envelope.Signature, publicKeys...); err != nil {
+		return nil, err
+	}
+	return envelope.Payload, nil
+}
+
+// SignedLoader returns a loader which combines a payload and its detached signature into an [Envelope].
+func SignedLoader(payload translator.Loader, signature translator.Loader) translator.Loader {
+	return loaderFunc(func() ([]byte, error) {
+		content, err := payload.Load()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := signature.Load()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(&Envelope{
+			Payload:   content,
+			Signature: sig,
+		})
+	})
+}
+
+// VerifiedLoader returns a loader which verifies the [Envelope] loaded by the given loader
+// and returns its payload.
+func VerifiedLoader(loader translator.Loader, publicKeys ...ed25519.PublicKey) translator.Loader {
+	return loaderFunc(func() ([]byte, error) {
+		content, err := loader.Load()
+		if err != nil {
+			return nil, err
+		}
+		return Open(content, publicKeys...)
+	})
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+
+	"github.com/gopi-frame/contract/translator"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func testJSONParser() translator.Parser {
+	return ParserFunc(func(data []byte) (translator.MessagePack, error) {
+		var items []map[string]string
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		var messages []translator.Message
+		for _, item := range items {
+			messages = append(messages, Message(&i18n.Message{
+				ID:    item["id"],
+				Other: item["other"],
+			}))
+		}
+		return MessagePack(messages, language.English), nil
+	})
+}
+
+func TestSign(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	payload := []byte(`[{"id": "test", "other": "test one"}]`)
+
+	t.Run("envelope", func(t *testing.T) {
+		envelope, err := Sign(privateKey, payload)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		content, err := Open(envelope, publicKey)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, content)
+	})
+
+	t.Run("detached", func(t *testing.T) {
+		signature := SignDetached(privateKey, payload)
+		assert.NoError(t, Verify(payload, signature, publicKey))
+		assert.ErrorIs(t, Verify([]byte(`[{"id": "test", "other": "phishing"}]`), signature, publicKey), ErrInvalidSignature)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		otherPublicKey, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		envelope, err := Sign(privateKey, payload)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		_, err = Open(envelope, otherPublicKey)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestI18n_SetPublicKeys(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	payload := []byte(`[{"id": "test", "other": "test one"}]`)
+
+	t.Run("load signed envelope", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			i.SetPublicKeys(publicKey)
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return Sign(privateKey, payload)
+			}), testJSONParser())
+			assert.NoError(t, err)
+			assert.Equal(t, "test one", i.T("test"))
+		}
+	})
+
+	t.Run("load detached signature", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			i.SetPublicKeys(publicKey)
+			err = i.LoadMessage(SignedLoader(LoaderFunc(func() ([]byte, error) {
+				return payload, nil
+			}), LoaderFunc(func() ([]byte, error) {
+				return SignDetached(privateKey, payload), nil
+			})), testJSONParser())
+			assert.NoError(t, err)
+			assert.Equal(t, "test one", i.T("test"))
+		}
+	})
+
+	t.Run("reject unsigned content", func(t *testing.T) {
+		i, err := New("en")
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		} else {
+			i.SetPublicKeys(publicKey)
+			err = i.LoadMessage(LoaderFunc(func() ([]byte, error) {
+				return payload, nil
+			}), testJSONParser())
+			assert.Error(t, err)
+			assert.Equal(t, "test", i.T("test"))
+		}
+	})
+}
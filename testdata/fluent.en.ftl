@@ -0,0 +1,7 @@
+-brand = Gopi
+
+welcome = Welcome to { -brand }, { $name }!
+emails = { $count ->
+    [one] { $count } new email
+   *[other] { $count } new emails
+}
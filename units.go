@@ -0,0 +1,340 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gopi-frame/exception"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	textmessage "golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Unit is a measurement unit formatted by [I18n.Unit].
+type Unit string
+
+// measurement units
+const (
+	UnitByte        Unit = "byte"
+	UnitKilobyte    Unit = "kilobyte"
+	UnitMegabyte    Unit = "megabyte"
+	UnitGigabyte    Unit = "gigabyte"
+	UnitTerabyte    Unit = "terabyte"
+	UnitMeter       Unit = "meter"
+	UnitKilometer   Unit = "kilometer"
+	UnitFoot        Unit = "foot"
+	UnitMile        Unit = "mile"
+	UnitGram        Unit = "gram"
+	UnitKilogram    Unit = "kilogram"
+	UnitPound       Unit = "pound"
+	UnitMillisecond Unit = "millisecond"
+	UnitSecond      Unit = "second"
+	UnitMinute      Unit = "minute"
+	UnitHour        Unit = "hour"
+	UnitDay         Unit = "day"
+)
+
+// UnitWidth is the width of a formatted unit, for example "5 kilometers", "5 km" and "5km".
+type UnitWidth int
+
+// unit widths
+const (
+	UnitLong UnitWidth = iota
+	UnitShort
+	UnitNarrow
+)
+
+// unitForms are the patterns of a unit for the CLDR plural forms "one" and "other", {0} is replaced by the value.
+type unitForms struct {
+	one   string
+	other string
+}
+
+func pluralUnit(one, other string) unitForms {
+	return unitForms{one: one, other: other}
+}
+
+func singleUnit(pattern string) unitForms {
+	return unitForms{one: pattern, other: pattern}
+}
+
+// unitPatterns are the CLDR unit patterns of the long, short and narrow widths by base language,
+// the languages without patterns use the English ones.
+var unitPatterns = map[string]map[Unit][3]unitForms{
+	"en": {
+		UnitByte:        {pluralUnit("{0} byte", "{0} bytes"), singleUnit("{0} byte"), singleUnit("{0}B")},
+		UnitKilobyte:    {pluralUnit("{0} kilobyte", "{0} kilobytes"), singleUnit("{0} kB"), singleUnit("{0}kB")},
+		UnitMegabyte:    {pluralUnit("{0} megabyte", "{0} megabytes"), singleUnit("{0} MB"), singleUnit("{0}MB")},
+		UnitGigabyte:    {pluralUnit("{0} gigabyte", "{0} gigabytes"), singleUnit("{0} GB"), singleUnit("{0}GB")},
+		UnitTerabyte:    {pluralUnit("{0} terabyte", "{0} terabytes"), singleUnit("{0} TB"), singleUnit("{0}TB")},
+		UnitMeter:       {pluralUnit("{0} meter", "{0} meters"), singleUnit("{0} m"), singleUnit("{0}m")},
+		UnitKilometer:   {pluralUnit("{0} kilometer", "{0} kilometers"), singleUnit("{0} km"), singleUnit("{0}km")},
+		UnitFoot:        {pluralUnit("{0} foot", "{0} feet"), singleUnit("{0} ft"), singleUnit("{0}′")},
+		UnitMile:        {pluralUnit("{0} mile", "{0} miles"), singleUnit("{0} mi"), singleUnit("{0}mi")},
+		UnitGram:        {pluralUnit("{0} gram", "{0} grams"), singleUnit("{0} g"), singleUnit("{0}g")},
+		UnitKilogram:    {pluralUnit("{0} kilogram", "{0} kilograms"), singleUnit("{0} kg"), singleUnit("{0}kg")},
+		UnitPound:       {pluralUnit("{0} pound", "{0} pounds"), singleUnit("{0} lb"), singleUnit("{0}lb")},
+		UnitMillisecond: {pluralUnit("{0} millisecond", "{0} milliseconds"), singleUnit("{0} ms"), singleUnit("{0}ms")},
+		UnitSecond:      {pluralUnit("{0} second", "{0} seconds"), singleUnit("{0} sec"), singleUnit("{0}s")},
+		UnitMinute:      {pluralUnit("{0} minute", "{0} minutes"), singleUnit("{0} min"), singleUnit("{0}m")},
+		UnitHour:        {pluralUnit("{0} hour", "{0} hours"), singleUnit("{0} hr"), singleUnit("{0}h")},
+		UnitDay:         {pluralUnit("{0} day", "{0} days"), pluralUnit("{0} day", "{0} days"), singleUnit("{0}d")},
+	},
+	"de": {
+		UnitByte:        {singleUnit("{0} Byte"), singleUnit("{0} Byte"), singleUnit("{0} B")},
+		UnitKilobyte:    {singleUnit("{0} Kilobyte"), singleUnit("{0} kB"), singleUnit("{0} kB")},
+		UnitMegabyte:    {singleUnit("{0} Megabyte"), singleUnit("{0} MB"), singleUnit("{0} MB")},
+		UnitGigabyte:    {singleUnit("{0} Gigabyte"), singleUnit("{0} GB"), singleUnit("{0} GB")},
+		UnitTerabyte:    {singleUnit("{0} Terabyte"), singleUnit("{0} TB"), singleUnit("{0} TB")},
+		UnitMeter:       {singleUnit("{0} Meter"), singleUnit("{0} m"), singleUnit("{0} m")},
+		UnitKilometer:   {singleUnit("{0} Kilometer"), singleUnit("{0} km"), singleUnit("{0} km")},
+		UnitFoot:        {singleUnit("{0} Fuß"), singleUnit("{0} ft"), singleUnit("{0} ft")},
+		UnitMile:        {pluralUnit("{0} Meile", "{0} Meilen"), singleUnit("{0} mi"), singleUnit("{0} mi")},
+		UnitGram:        {singleUnit("{0} Gramm"), singleUnit("{0} g"), singleUnit("{0} g")},
+		UnitKilogram:    {singleUnit("{0} Kilogramm"), singleUnit("{0} kg"), singleUnit("{0} kg")},
+		UnitPound:       {singleUnit("{0} Pfund"), singleUnit("{0} lb"), singleUnit("{0} lb")},
+		UnitMillisecond: {pluralUnit("{0} Millisekunde", "{0} Millisekunden"), singleUnit("{0} ms"), singleUnit("{0} ms")},
+		UnitSecond:      {pluralUnit("{0} Sekunde", "{0} Sekunden"), singleUnit("{0} Sek."), singleUnit("{0} Sek.")},
+		UnitMinute:      {pluralUnit("{0} Minute", "{0} Minuten"), singleUnit("{0} Min."), singleUnit("{0} Min.")},
+		UnitHour:        {pluralUnit("{0} Stunde", "{0} Stunden"), singleUnit("{0} Std."), singleUnit("{0} Std.")},
+		UnitDay:         {pluralUnit("{0} Tag", "{0} Tage"), pluralUnit("{0} Tg.", "{0} Tg."), singleUnit("{0} T")},
+	},
+	"fr": {
+		UnitByte:        {pluralUnit("{0} octet", "{0} octets"), singleUnit("{0} o"), singleUnit("{0}o")},
+		UnitKilobyte:    {pluralUnit("{0} kilooctet", "{0} kilooctets"), singleUnit("{0} ko"), singleUnit("{0}ko")},
+		UnitMegabyte:    {pluralUnit("{0} mégaoctet", "{0} mégaoctets"), singleUnit("{0} Mo"), singleUnit("{0}Mo")},
+		UnitGigabyte:    {pluralUnit("{0} gigaoctet", "{0} gigaoctets"), singleUnit("{0} Go"), singleUnit("{0}Go")},
+		UnitTerabyte:    {pluralUnit("{0} téraoctet", "{0} téraoctets"), singleUnit("{0} To"), singleUnit("{0}To")},
+		UnitMeter:       {pluralUnit("{0} mètre", "{0} mètres"), singleUnit("{0} m"), singleUnit("{0}m")},
+		UnitKilometer:   {pluralUnit("{0} kilomètre", "{0} kilomètres"), singleUnit("{0} km"), singleUnit("{0}km")},
+		UnitFoot:        {pluralUnit("{0} pied", "{0} pieds"), singleUnit("{0} pi"), singleUnit("{0}′")},
+		UnitMile:        {pluralUnit("{0} mile", "{0} miles"), singleUnit("{0} mi"), singleUnit("{0}mi")},
+		UnitGram:        {pluralUnit("{0} gramme", "{0} grammes"), singleUnit("{0} g"), singleUnit("{0}g")},
+		UnitKilogram:    {pluralUnit("{0} kilogramme", "{0} kilogrammes"), singleUnit("{0} kg"), singleUnit("{0}kg")},
+		UnitPound:       {pluralUnit("{0} livre", "{0} livres"), singleUnit("{0} lb"), singleUnit("{0}lb")},
+		UnitMillisecond: {pluralUnit("{0} milliseconde", "{0} millisecondes"), singleUnit("{0} ms"), singleUnit("{0}ms")},
+		UnitSecond:      {pluralUnit("{0} seconde", "{0} secondes"), singleUnit("{0} s"), singleUnit("{0}s")},
+		UnitMinute:      {pluralUnit("{0} minute", "{0} minutes"), singleUnit("{0} min"), singleUnit("{0}min")},
+		UnitHour:        {pluralUnit("{0} heure", "{0} heures"), singleUnit("{0} h"), singleUnit("{0}h")},
+		UnitDay:         {pluralUnit("{0} jour", "{0} jours"), singleUnit("{0} j"), singleUnit("{0}j")},
+	},
+	"es": {
+		UnitByte:        {pluralUnit("{0} byte", "{0} bytes"), singleUnit("{0} B"), singleUnit("{0}B")},
+		UnitKilobyte:    {pluralUnit("{0} kilobyte", "{0} kilobytes"), singleUnit("{0} kB"), singleUnit("{0}kB")},
+		UnitMegabyte:    {pluralUnit("{0} megabyte", "{0} megabytes"), singleUnit("{0} MB"), singleUnit("{0}MB")},
+		UnitGigabyte:    {pluralUnit("{0} gigabyte", "{0} gigabytes"), singleUnit("{0} GB"), singleUnit("{0}GB")},
+		UnitTerabyte:    {pluralUnit("{0} terabyte", "{0} terabytes"), singleUnit("{0} TB"), singleUnit("{0}TB")},
+		UnitMeter:       {pluralUnit("{0} metro", "{0} metros"), singleUnit("{0} m"), singleUnit("{0}m")},
+		UnitKilometer:   {pluralUnit("{0} kilómetro", "{0} kilómetros"), singleUnit("{0} km"), singleUnit("{0}km")},
+		UnitFoot:        {pluralUnit("{0} pie", "{0} pies"), singleUnit("{0} ft"), singleUnit("{0}ft")},
+		UnitMile:        {pluralUnit("{0} milla", "{0} millas"), singleUnit("{0} mi"), singleUnit("{0}mi")},
+		UnitGram:        {pluralUnit("{0} gramo", "{0} gramos"), singleUnit("{0} g"), singleUnit("{0}g")},
+		UnitKilogram:    {pluralUnit("{0} kilogramo", "{0} kilogramos"), singleUnit("{0} kg"), singleUnit("{0}kg")},
+		UnitPound:       {pluralUnit("{0} libra", "{0} libras"), singleUnit("{0} lb"), singleUnit("{0}lb")},
+		UnitMillisecond: {pluralUnit("{0} milisegundo", "{0} milisegundos"), singleUnit("{0} ms"), singleUnit("{0}ms")},
+		UnitSecond:      {pluralUnit("{0} segundo", "{0} segundos"), singleUnit("{0} s"), singleUnit("{0}s")},
+		UnitMinute:      {pluralUnit("{0} minuto", "{0} minutos"), singleUnit("{0} min"), singleUnit("{0}min")},
+		UnitHour:        {pluralUnit("{0} hora", "{0} horas"), singleUnit("{0} h"), singleUnit("{0}h")},
+		UnitDay:         {pluralUnit("{0} día", "{0} días"), singleUnit("{0} d"), singleUnit("{0}d")},
+	},
+}
+
+var byteUnits = []Unit{UnitByte, UnitKilobyte, UnitMegabyte, UnitGigabyte, UnitTerabyte}
+
+// formatUnit formats the value rounded to maxFractionDigits with the unit patterns of the language.
+func formatUnit(tag language.Tag, value float64, maxFractionDigits int, unit Unit, width UnitWidth) (string, error) {
+	base, _ := tag.Base()
+	patterns, ok := unitPatterns[base.String()]
+	if !ok {
+		patterns = unitPatterns["en"]
+	}
+	forms, ok := patterns[unit]
+	if !ok {
+		return "", exception.New(fmt.Sprintf("unknown unit %q", unit))
+	}
+	if width < UnitLong || width > UnitNarrow {
+		width = UnitLong
+	}
+	scale := math.Pow10(maxFractionDigits)
+	value = math.Round(value*scale) / scale
+	pattern := forms[width].other
+	if pluralForm(tag, value) == plural.One {
+		pattern = forms[width].one
+	}
+	formatted := textmessage.NewPrinter(tag).Sprint(number.Decimal(value, number.MaxFractionDigits(maxFractionDigits)))
+	return strings.Replace(pattern, "{0}", formatted, 1), nil
+}
+
+// pluralForm returns the CLDR cardinal plural form of the decimal value.
+func pluralForm(tag language.Tag, value float64) plural.Form {
+	integer, fraction, _ := strings.Cut(strconv.FormatFloat(math.Abs(value), 'f', -1, 64), ".")
+	i, _ := strconv.Atoi(integer)
+	f, _ := strconv.Atoi("0" + fraction)
+	return plural.Cardinal.MatchPlural(tag, i, len(fraction), len(fraction), f, f)
+}
+
+// Unit formats the measurement value in the language resolved by the translator, for example,
+// "5 kilometers", "5 km" and "5km" in English and "5 Kilometer" in German with the long, short and narrow widths.
+// The value is rounded to 2 fraction digits.
+//
+// In the message templates, the unit function takes the value, the unit and an optional width
+// "long", "short" or "narrow":
+//
+//	{{unit .distance "kilometer" "short"}}
+func (i *I18n) Unit(value float64, unit Unit, width UnitWidth) string {
+	s, err := formatUnit(i.matchedTag(), value, 2, unit, width)
+	if err != nil {
+		return strconv.FormatFloat(value, 'f', -1, 64) + " " + string(unit)
+	}
+	return s
+}
+
+// Bytes formats the size with the largest byte unit whose value is at least 1, for example "1.5 MB".
+// The units are decimal, a kilobyte is 1000 bytes, and the value is rounded to 1 fraction digit.
+//
+// In the message templates, the bytes function takes the size and an optional width:
+//
+//	{{bytes .size "short"}}
+func (i *I18n) Bytes(size int64, width UnitWidth) string {
+	value := float64(size)
+	unit := byteUnits[0]
+	for _, u := range byteUnits[1:] {
+		// the value is rounded first, so that 999,950 bytes are formatted as 1 MB instead of 1,000 kB.
+		if math.Abs(math.Round(value*10)/10) < 1000 {
+			break
+		}
+		value /= 1000
+		unit = u
+	}
+	s, _ := formatUnit(i.matchedTag(), math.Round(value*10)/10, 1, unit, width)
+	return s
+}
+
+// Duration formats the duration as days, hours, minutes and seconds in the language resolved by the translator,
+// for example, "2 hours 5 minutes" in English and "2 Std. 5 Min." in German with the long and short widths.
+// The zero components are omitted, the durations shorter than a second are formatted as milliseconds
+// and the sign is ignored.
+//
+// In the message templates, the duration function takes the duration and an optional width:
+//
+//	{{duration .elapsed "narrow"}}
+func (i *I18n) Duration(d time.Duration, width UnitWidth) string {
+	tag := i.matchedTag()
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Second {
+		s, _ := formatUnit(tag, float64(d.Milliseconds()), 0, UnitMillisecond, width)
+		if d == 0 {
+			s, _ = formatUnit(tag, 0, 0, UnitSecond, width)
+		}
+		return s
+	}
+	var parts []string
+	for _, component := range []struct {
+		unit     Unit
+		duration time.Duration
+	}{
+		{UnitDay, 24 * time.Hour},
+		{UnitHour, time.Hour},
+		{UnitMinute, time.Minute},
+		{UnitSecond, time.Second},
+	} {
+		if n := d / component.duration; n > 0 {
+			s, _ := formatUnit(tag, float64(n), 0, component.unit, width)
+			parts = append(parts, s)
+			d -= n * component.duration
+		}
+	}
+	separator := " "
+	if base, _ := tag.Base(); base.String() == "zh" || base.String() == "ja" {
+		separator = ""
+	}
+	return strings.Join(parts, separator)
+}
+
+// unitWidth parses the optional width argument of the template functions.
+func unitWidth(name string, args []string) (UnitWidth, error) {
+	if len(args) == 0 {
+		return UnitLong, nil
+	}
+	if len(args) > 1 {
+		return UnitLong, exception.New(fmt.Sprintf("%s: too many arguments", name))
+	}
+	switch args[0] {
+	case "long":
+		return UnitLong, nil
+	case "short":
+		return UnitShort, nil
+	case "narrow":
+		return UnitNarrow, nil
+	}
+	return UnitLong, exception.New(fmt.Sprintf("%s: unknown width %q", name, args[0]))
+}
+
+// unitFunc is the unit template function, see [I18n.Unit].
+func (i *I18n) unitFunc(value any, unit string, width ...string) (string, error) {
+	w, err := unitWidth("unit", width)
+	if err != nil {
+		return "", err
+	}
+	v, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+	return formatUnit(i.matchedTag(), v, 2, Unit(unit), w)
+}
+
+// bytesFunc is the bytes template function, see [I18n.Bytes].
+func (i *I18n) bytesFunc(size any, width ...string) (string, error) {
+	w, err := unitWidth("bytes", width)
+	if err != nil {
+		return "", err
+	}
+	v, err := toFloat(size)
+	if err != nil {
+		return "", err
+	}
+	return i.Bytes(int64(v), w), nil
+}
+
+// durationFunc is the duration template function, see [I18n.Duration], the duration can be a [time.Duration]
+// or a string parsed by [time.ParseDuration].
+func (i *I18n) durationFunc(d any, width ...string) (string, error) {
+	w, err := unitWidth("duration", width)
+	if err != nil {
+		return "", err
+	}
+	switch v := d.(type) {
+	case time.Duration:
+		return i.Duration(v, w), nil
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return "", err
+		}
+		return i.Duration(duration, w), nil
+	}
+	return "", exception.New(fmt.Sprintf("duration: expected a duration, got %T", d))
+}
+
+// toFloat converts the number or numeric string to float64.
+func toFloat(v any) (float64, error) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return strconv.ParseFloat(value.String(), 64)
+	}
+	return 0, exception.New(fmt.Sprintf("expected a number, got %T", v))
+}
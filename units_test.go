@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestI18n_Unit(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		for _, l := range []string{"de", "fr"} {
+			if err := i.AddMessages(l, Message(&i18n.Message{ID: "test", Other: l})); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+		}
+		assert.Equal(t, "1 kilometer", i.Unit(1, UnitKilometer, UnitLong))
+		assert.Equal(t, "1.5 kilometers", i.Unit(1.5, UnitKilometer, UnitLong))
+		assert.Equal(t, "1,234.57 km", i.Unit(1234.567, UnitKilometer, UnitShort))
+		assert.Equal(t, "3mi", i.Unit(3, UnitMile, UnitNarrow))
+		assert.Equal(t, "1 foot", i.Unit(1, UnitFoot, UnitLong))
+		assert.Equal(t, "2 feet", i.Unit(2, UnitFoot, UnitLong))
+		de := i.Locale("de").(*I18n)
+		assert.Equal(t, "1,5 Kilometer", de.Unit(1.5, UnitKilometer, UnitLong))
+		assert.Equal(t, "1 Meile", de.Unit(1, UnitMile, UnitLong))
+		assert.Equal(t, "2 Meilen", de.Unit(2, UnitMile, UnitLong))
+		fr := i.Locale("fr").(*I18n)
+		assert.Equal(t, "1,5 kilogramme", fr.Unit(1.5, UnitKilogram, UnitLong))
+		assert.Equal(t, "2 kilogrammes", fr.Unit(2, UnitKilogram, UnitLong))
+		assert.Equal(t, "2 unknown", i.Unit(2, Unit("unknown"), UnitLong))
+	}
+}
+
+func TestI18n_Bytes(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		if err := i.AddMessages("fr", Message(&i18n.Message{ID: "test", Other: "fr"})); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "1 byte", i.Bytes(1, UnitLong))
+		assert.Equal(t, "999 byte", i.Bytes(999, UnitShort))
+		assert.Equal(t, "1.5 MB", i.Bytes(1_500_000, UnitShort))
+		assert.Equal(t, "999.9 kB", i.Bytes(999_949, UnitShort))
+		assert.Equal(t, "1 MB", i.Bytes(999_950, UnitShort))
+		assert.Equal(t, "1 kB", i.Bytes(1_000, UnitShort))
+		assert.Equal(t, "-1 MB", i.Bytes(-999_950, UnitShort))
+		assert.Equal(t, "2 gigabytes", i.Bytes(2_000_000_000, UnitLong))
+		assert.Equal(t, "1,5 Mo", i.Locale("fr").(*I18n).Bytes(1_500_000, UnitShort))
+	}
+}
+
+func TestI18n_Duration(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		if err := i.AddMessages("de", Message(&i18n.Message{ID: "test", Other: "de"})); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		d := 2*time.Hour + 5*time.Minute
+		assert.Equal(t, "2 hours 5 minutes", i.Duration(d, UnitLong))
+		assert.Equal(t, "2 hr 5 min", i.Duration(d, UnitShort))
+		assert.Equal(t, "2h 5m", i.Duration(d, UnitNarrow))
+		assert.Equal(t, "1 day 1 second", i.Duration(24*time.Hour+time.Second, UnitLong))
+		assert.Equal(t, "250 milliseconds", i.Duration(250*time.Millisecond, UnitLong))
+		assert.Equal(t, "0 seconds", i.Duration(0, UnitLong))
+		assert.Equal(t, "1 minute", i.Duration(-time.Minute, UnitLong))
+		de := i.Locale("de").(*I18n)
+		assert.Equal(t, "2 Std. 5 Min.", de.Duration(d, UnitShort))
+		assert.Equal(t, "2 Stunden 1 Minute", de.Duration(2*time.Hour+time.Minute, UnitLong))
+	}
+}
+
+func TestI18n_UnitFuncs(t *testing.T) {
+	i, err := New("en")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	} else {
+		err = i.AddMessages("en",
+			Message(&i18n.Message{ID: "distance", Other: "{{unit .distance \"kilometer\" \"short\"}} away"}),
+			Message(&i18n.Message{ID: "download", Other: "Downloaded {{bytes .size}} in {{duration .elapsed}}"}),
+			Message(&i18n.Message{ID: "timeout", Other: "Retry in {{duration .after \"narrow\"}}"}),
+		)
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		err = i.AddMessages("de", Message(&i18n.Message{ID: "download", Other: "{{bytes .size \"short\"}} in {{duration .elapsed \"short\"}} heruntergeladen"}))
+		if err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.Equal(t, "2.5 km away", i.T("distance", "distance", 2.5))
+		assert.Equal(t, "Downloaded 3.2 megabytes in 1 minute 30 seconds", i.T("download", "size", 3_200_000, "elapsed", 90*time.Second))
+		assert.Equal(t, "3,2 MB in 1 Min. 30 Sek. heruntergeladen", i.Locale("de").T("download", "size", 3_200_000, "elapsed", 90*time.Second))
+		assert.Equal(t, "Retry in 1m 30s", i.T("timeout", "after", "90s"))
+	}
+}